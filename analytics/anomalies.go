@@ -0,0 +1,227 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package analytics
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"hailo/apiserver"
+	"hailo/conf"
+	"sort"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+// Rules detecting sensor anomalies in the readings of a bin
+const (
+	AnomalyStuckReading      = "stuck_reading"
+	AnomalyImplausibleJump   = "implausible_jump"
+	AnomalyFillDecrease      = "fill_decrease"
+	AnomalyOpeningsBackwards = "openings_backwards"
+)
+
+// stuckDuration is the minimum time a fill level is unchanged while the bin is opened to be stuck
+const stuckDuration = 7 * 24 * time.Hour
+
+// stuckOpenings is the minimum number of openings during an unchanged fill level to be stuck
+const stuckOpenings = 10
+
+// jumpLevel is the minimum change of the fill level in percent points to and back from a reading to be implausible
+const jumpLevel = 50.0
+
+// jumpWindow is the maximum time from the reading before to the reading after an implausible jump
+const jumpWindow = time.Hour
+
+// decreaseLevel is the minimum decrease of the fill level in percent points which requires an emptying
+const decreaseLevel = 20.0
+
+// SensorAnomalies detects anomalies in the readings of each bin. The readings have to be ordered by device and
+// timestamp. The anomalies are ordered by device and timestamp as well.
+func SensorAnomalies(readings []conf.Reading) []apiserver.SensorAnomaly {
+	anomalies := []apiserver.SensorAnomaly{}
+	for _, deviceReadings := range readingsByDevice(readings) {
+		anomalies = append(anomalies, binAnomalies(deviceReadings)...)
+	}
+	return anomalies
+}
+
+// SensorFault returns true, if an anomaly lasted until the given time or later
+func SensorFault(anomalies []apiserver.SensorAnomaly, since time.Time) bool {
+	for _, anomaly := range anomalies {
+		if !anomaly.Until.Before(since) {
+			return true
+		}
+	}
+	return false
+}
+
+// binAnomalies evaluates all rules on the readings of a bin ordered by timestamp. The decrease returning from an
+// implausible jump is not reported again.
+func binAnomalies(readings []conf.Reading) []apiserver.SensorAnomaly {
+	var anomalies []apiserver.SensorAnomaly
+	spike := false
+	for i := 1; i < len(readings); i++ {
+		previous, reading := readings[i-1], readings[i]
+		if spike {
+			spike = false
+			continue
+		}
+		if i+1 < len(readings) && isSpike(previous, reading, readings[i+1]) {
+			anomalies = append(anomalies, newAnomaly(AnomalyImplausibleJump, previous, reading,
+				fmt.Sprintf("fill level jumped from %s %% to %s %% and back within %.0f minutes", formatLevel(previous), formatLevel(reading), jumpWindow.Minutes())))
+			spike = true
+			continue
+		}
+		switch {
+		case levelDrop(previous, reading) >= decreaseLevel && !serviceChanged(previous, reading) && !openingsDecreased(previous, reading):
+			anomalies = append(anomalies, newAnomaly(AnomalyFillDecrease, previous, reading,
+				fmt.Sprintf("fill level decreased from %s %% to %s %% without emptying", formatLevel(previous), formatLevel(reading))))
+		case openingsDecreased(previous, reading) && !serviceChanged(previous, reading) &&
+			previous.Volumepercent != nil && reading.Volumepercent != nil && levelDrop(previous, reading) < decreaseLevel:
+			anomalies = append(anomalies, newAnomaly(AnomalyOpeningsBackwards, previous, reading,
+				fmt.Sprintf("openings decreased from %d to %d without emptying", *previous.Openings, *reading.Openings)))
+		}
+	}
+	anomalies = append(anomalies, stuckReadings(readings)...)
+	sort.SliceStable(anomalies, func(i, j int) bool { return anomalies[i].Timestamp.Before(anomalies[j].Timestamp) })
+	return anomalies
+}
+
+// stuckReadings returns an anomaly for each period in which the fill level of the bin did not change for at least
+// the stuck duration while the bin was opened at least the stuck number of times
+func stuckReadings(readings []conf.Reading) []apiserver.SensorAnomaly {
+	var anomalies []apiserver.SensorAnomaly
+	start, openings := 0, 0
+	for i := 1; i <= len(readings); i++ {
+		if i < len(readings) && sameLevel(readings[start], readings[i]) {
+			openings += openingsBetween(readings[i-1], readings[i])
+			continue
+		}
+		first, last := readings[start], readings[i-1]
+		if first.Volumepercent != nil && last.Timestamp.Sub(first.Timestamp) >= stuckDuration && openings >= stuckOpenings {
+			anomalies = append(anomalies, newAnomaly(AnomalyStuckReading, first, last,
+				fmt.Sprintf("fill level stuck at %s %% for %.0f days while opened %d times", formatLevel(first), last.Timestamp.Sub(first.Timestamp).Hours()/24, openings)))
+		}
+		start, openings = i, 0
+	}
+	return anomalies
+}
+
+// newAnomaly returns the anomaly of the rule detected from the previous to the current reading
+func newAnomaly(rule string, previous conf.Reading, reading conf.Reading, description string) apiserver.SensorAnomaly {
+	anomaly := apiserver.SensorAnomaly{
+		DeviceId:              reading.DeviceId,
+		Rule:                  rule,
+		Timestamp:             previous.Timestamp,
+		Until:                 reading.Timestamp,
+		PreviousVolumepercent: previous.Volumepercent,
+		Volumepercent:         reading.Volumepercent,
+		PreviousOpenings:      previous.Openings,
+		Openings:              reading.Openings,
+		Description:           description,
+	}
+	if reading.StationId != "" {
+		anomaly.StationId = common.Ptr(reading.StationId)
+	}
+	return anomaly
+}
+
+// isSpike returns true, if the fill level of the reading jumps from the previous level and back to the next level
+// in the same direction, all within the jump window
+func isSpike(previous conf.Reading, reading conf.Reading, next conf.Reading) bool {
+	if previous.Volumepercent == nil || reading.Volumepercent == nil || next.Volumepercent == nil ||
+		next.Timestamp.Sub(previous.Timestamp) > jumpWindow {
+		return false
+	}
+	up := *reading.Volumepercent - *previous.Volumepercent
+	down := *reading.Volumepercent - *next.Volumepercent
+	return (up >= jumpLevel && down >= jumpLevel) || (up <= -jumpLevel && down <= -jumpLevel)
+}
+
+// levelDrop returns the decrease of the fill level between the readings or 0, if a fill level is unknown
+func levelDrop(previous conf.Reading, reading conf.Reading) float64 {
+	if previous.Volumepercent == nil || reading.Volumepercent == nil {
+		return 0
+	}
+	return *previous.Volumepercent - *reading.Volumepercent
+}
+
+// serviceChanged returns true, if the last service changed between the readings
+func serviceChanged(previous conf.Reading, reading conf.Reading) bool {
+	return reading.LastService != nil && (previous.LastService == nil || reading.LastService.After(*previous.LastService))
+}
+
+// openingsDecreased returns true, if the openings since the last emptying decreased between the readings
+func openingsDecreased(previous conf.Reading, reading conf.Reading) bool {
+	return previous.Openings != nil && reading.Openings != nil && *reading.Openings < *previous.Openings
+}
+
+// openingsBetween returns the openings between the readings. If the openings since the last emptying were reset, the
+// openings of the reading are counted.
+func openingsBetween(previous conf.Reading, reading conf.Reading) int {
+	if reading.Openings == nil {
+		return 0
+	}
+	if openingsDecreased(previous, reading) || previous.Openings == nil {
+		return int(*reading.Openings)
+	}
+	return int(*reading.Openings - *previous.Openings)
+}
+
+// sameLevel returns true, if both readings have the same known fill level
+func sameLevel(reading conf.Reading, other conf.Reading) bool {
+	return reading.Volumepercent != nil && other.Volumepercent != nil && *reading.Volumepercent == *other.Volumepercent
+}
+
+// formatLevel formats the fill level of the reading
+func formatLevel(reading conf.Reading) string {
+	if reading.Volumepercent == nil {
+		return "unknown"
+	}
+	return formatNumber(*reading.Volumepercent)
+}
+
+// SensorAnomaliesCsv returns the anomalies of the report as CSV with one line for each anomaly
+func SensorAnomaliesCsv(report apiserver.SensorAnomalyReport) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	err := writer.Write([]string{"device_id", "station_id", "rule", "timestamp", "until", "previous_volumepercent",
+		"volumepercent", "previous_openings", "openings", "description"})
+	if err != nil {
+		return nil, err
+	}
+	for _, anomaly := range report.Anomalies {
+		err = writer.Write([]string{
+			anomaly.DeviceId,
+			common.Val(anomaly.StationId),
+			anomaly.Rule,
+			anomaly.Timestamp.Format(time.RFC3339),
+			anomaly.Until.Format(time.RFC3339),
+			csvNumber(anomaly.PreviousVolumepercent),
+			csvNumber(anomaly.Volumepercent),
+			csvNumber(anomaly.PreviousOpenings),
+			csvNumber(anomaly.Openings),
+			anomaly.Description,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
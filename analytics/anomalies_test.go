@@ -0,0 +1,77 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package analytics
+
+import (
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"hailo/apiserver"
+	"hailo/conf"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSensorAnomalies(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	readings := []conf.Reading{
+		{DeviceId: "a", Timestamp: start, Volumepercent: common.Ptr(10.0), Openings: common.Ptr[int32](1)},
+		{DeviceId: "a", Timestamp: start.Add(30 * time.Minute), Volumepercent: common.Ptr(100.0), Openings: common.Ptr[int32](1)},
+		{DeviceId: "a", Timestamp: start.Add(time.Hour), Volumepercent: common.Ptr(10.0), Openings: common.Ptr[int32](1)},
+		{DeviceId: "a", Timestamp: start.Add(2 * time.Hour), Volumepercent: common.Ptr(60.0), Openings: common.Ptr[int32](5)},
+		{DeviceId: "a", Timestamp: start.Add(3 * time.Hour), Volumepercent: common.Ptr(30.0), Openings: common.Ptr[int32](6)},
+		{DeviceId: "a", Timestamp: start.Add(4 * time.Hour), Volumepercent: common.Ptr(30.0), Openings: common.Ptr[int32](2)},
+		{DeviceId: "a", Timestamp: start.Add(5 * time.Hour), Volumepercent: common.Ptr(0.0), Openings: common.Ptr[int32](0), LastService: common.Ptr(start.Add(5 * time.Hour))},
+	}
+	for day := 1; day <= 9; day++ {
+		readings = append(readings, conf.Reading{DeviceId: "a", Timestamp: start.AddDate(0, 0, day), Volumepercent: common.Ptr(40.0), Openings: common.Ptr(int32(2 * day))})
+	}
+	readings = append(readings,
+		conf.Reading{DeviceId: "b", Timestamp: start, Volumepercent: common.Ptr(10.0), Openings: common.Ptr[int32](1)},
+		conf.Reading{DeviceId: "b", Timestamp: start.Add(time.Hour), Volumepercent: common.Ptr(20.0), Openings: common.Ptr[int32](3)},
+		conf.Reading{DeviceId: "b", Timestamp: start.Add(2 * time.Hour), Volumepercent: common.Ptr(0.0), Openings: common.Ptr[int32](0)},
+		conf.Reading{DeviceId: "b", Timestamp: start.Add(24 * time.Hour), Volumepercent: common.Ptr(40.0), Openings: common.Ptr[int32](8)},
+	)
+
+	anomalies := SensorAnomalies(readings)
+	var rules []string
+	for _, anomaly := range anomalies {
+		assert.Equal(t, "a", anomaly.DeviceId)
+		rules = append(rules, anomaly.Rule)
+	}
+	assert.Equal(t, []string{AnomalyImplausibleJump, AnomalyFillDecrease, AnomalyOpeningsBackwards, AnomalyStuckReading}, rules)
+	assert.Equal(t, "fill level jumped from 10 % to 100 % and back within 60 minutes", anomalies[0].Description)
+	assert.Equal(t, start.Add(24*time.Hour), anomalies[3].Timestamp)
+	assert.Equal(t, start.Add(9*24*time.Hour), anomalies[3].Until)
+
+	assert.True(t, SensorFault(anomalies, start.Add(8*24*time.Hour)))
+	assert.False(t, SensorFault(anomalies, start.Add(10*24*time.Hour)))
+
+	csv, err := SensorAnomaliesCsv(apiserver.SensorAnomalyReport{Anomalies: anomalies})
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(csv)), "\n")
+	assert.Len(t, lines, 5)
+	assert.Equal(t, "a,,fill_decrease,2023-01-01T02:00:00Z,2023-01-01T03:00:00Z,60,30,5,6,fill level decreased from 60 % to 30 % without emptying", lines[2])
+}
+
+func TestIsSpike(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	previous := conf.Reading{Timestamp: start, Volumepercent: common.Ptr(10.0)}
+	reading := conf.Reading{Timestamp: start.Add(40 * time.Minute), Volumepercent: common.Ptr(100.0)}
+	assert.True(t, isSpike(previous, reading, conf.Reading{Timestamp: start.Add(time.Hour), Volumepercent: common.Ptr(10.0)}))
+	assert.False(t, isSpike(previous, reading, conf.Reading{Timestamp: start.Add(80 * time.Minute), Volumepercent: common.Ptr(10.0)}))
+	assert.False(t, isSpike(previous, reading, conf.Reading{Timestamp: start.Add(time.Hour), Volumepercent: common.Ptr(60.0)}))
+}
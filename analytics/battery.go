@@ -0,0 +1,68 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package analytics
+
+import (
+	"hailo/conf"
+	"time"
+)
+
+// replacementJump is the increase of the battery level in percent points which indicates a replaced battery
+const replacementJump = 20.0
+
+// minForecastDays is the minimum number of days covered by the battery levels to forecast the depletion
+const minForecastDays = 2.0
+
+// BatteryDaysLeft forecasts the days from now until the battery level falls to the cut-off level. The levels have to
+// be ordered by timestamp. The depletion rate is the linear regression of the levels since the last battery
+// replacement. If the levels cover too few days or don't decrease, nil is returned.
+func BatteryDaysLeft(readings []conf.BatteryReading, cutoff float64, now time.Time) *float64 {
+	for i := len(readings) - 1; i > 0; i-- {
+		if readings[i].BatLevel >= readings[i-1].BatLevel+replacementJump {
+			readings = readings[i:]
+			break
+		}
+	}
+	if len(readings) < 2 {
+		return nil
+	}
+	first, last := readings[0], readings[len(readings)-1]
+	if last.Timestamp.Sub(first.Timestamp).Hours()/24 < minForecastDays {
+		return nil
+	}
+
+	// least squares fit of the level over the days since the first reading
+	var sumDays, sumLevels, sumSquares, sumProducts float64
+	for _, reading := range readings {
+		days := reading.Timestamp.Sub(first.Timestamp).Hours() / 24
+		sumDays += days
+		sumLevels += reading.BatLevel
+		sumSquares += days * days
+		sumProducts += days * reading.BatLevel
+	}
+	count := float64(len(readings))
+	slope := (count*sumProducts - sumDays*sumLevels) / (count*sumSquares - sumDays*sumDays)
+	if slope >= 0 {
+		return nil
+	}
+
+	daysLeft := (last.BatLevel-cutoff)/-slope - now.Sub(last.Timestamp).Hours()/24
+	if daysLeft < 0 {
+		daysLeft = 0
+	}
+	daysLeft = round(daysLeft, 1)
+	return &daysLeft
+}
@@ -0,0 +1,51 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package analytics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"hailo/conf"
+	"testing"
+	"time"
+)
+
+func TestBatteryDaysLeft(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// one percent per day, 20 % left at day 10
+	readings := []conf.BatteryReading{
+		{Timestamp: start, BatLevel: 30},
+		{Timestamp: start.AddDate(0, 0, 5), BatLevel: 25},
+		{Timestamp: start.AddDate(0, 0, 10), BatLevel: 20},
+	}
+	assert.Equal(t, 10.0, *BatteryDaysLeft(readings, 10, start.AddDate(0, 0, 10)))
+	assert.Equal(t, 4.0, *BatteryDaysLeft(readings, 10, start.AddDate(0, 0, 16)))
+	assert.Equal(t, 0.0, *BatteryDaysLeft(readings, 10, start.AddDate(0, 0, 30)))
+
+	// replaced battery, only the levels since the replacement count
+	replaced := []conf.BatteryReading{
+		{Timestamp: start, BatLevel: 30},
+		{Timestamp: start.AddDate(0, 0, 5), BatLevel: 10},
+		{Timestamp: start.AddDate(0, 0, 6), BatLevel: 100},
+		{Timestamp: start.AddDate(0, 0, 7), BatLevel: 99},
+	}
+	assert.Nil(t, BatteryDaysLeft(replaced, 10, start.AddDate(0, 0, 7)))
+	replaced = append(replaced, conf.BatteryReading{Timestamp: start.AddDate(0, 0, 10), BatLevel: 96})
+	assert.Equal(t, 86.0, *BatteryDaysLeft(replaced, 10, start.AddDate(0, 0, 10)))
+
+	assert.Nil(t, BatteryDaysLeft([]conf.BatteryReading{{Timestamp: start, BatLevel: 30}, {Timestamp: start.AddDate(0, 0, 1), BatLevel: 20}}, 10, start))
+	assert.Nil(t, BatteryDaysLeft([]conf.BatteryReading{{Timestamp: start, BatLevel: 20}, {Timestamp: start.AddDate(0, 0, 5), BatLevel: 25}}, 10, start))
+}
@@ -0,0 +1,254 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package analytics
+
+import (
+	"bytes"
+	"encoding/csv"
+	"hailo/apiserver"
+	"hailo/conf"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+// Recommendations for the right-sizing of a bin
+const (
+	RecommendKeep              = "keep"
+	RecommendIncreaseVolume    = "increase_volume"
+	RecommendDecreaseVolume    = "decrease_volume"
+	RecommendIncreaseFrequency = "increase_frequency"
+	RecommendReduceFrequency   = "reduce_frequency"
+	RecommendInsufficientData  = "insufficient_data"
+)
+
+// DefaultTargetFill is the fill level in percent a bin should have when it is emptied, if no target is requested
+const DefaultTargetFill = 80.0
+
+// minObservationDays is the minimum number of days covered by the readings of a bin to recommend a change
+const minObservationDays = 7.0
+
+// minEmptyingsPerMonth is the emptying frequency a recommendation never goes below, e.g. for hygienic reasons
+const minEmptyingsPerMonth = 2.0
+
+// tolerance is the relative deviation of the needed from the observed emptying frequency which is accepted without
+// recommending a change
+const tolerance = 0.25
+
+// daysPerMonth is the average number of days in a month
+const daysPerMonth = 30.0
+
+// StandardVolumes are the common bin volumes in liters which are recommended
+var StandardVolumes = []int32{60, 80, 120, 240, 360, 660, 770, 1100, 3000, 5000}
+
+// fillHistory sums up the fill rate and emptyings of a bin from its readings
+type fillHistory struct {
+	days            float64
+	filled          float64
+	emptyings       int
+	fillAtEmptying  float64
+	fillsAtEmptying int
+}
+
+// RightSizing recommends volume or emptying frequency changes for the bins from the readings of the date range. The
+// readings have to be ordered by device and timestamp. The fill rate of a bin is the sum of the fill level increases
+// between the readings per day. A bin is right-sized, if it reaches the target fill level at each emptying. The trips
+// saved are compared with the observed emptyings, for a larger volume with the emptyings needed at the current volume.
+func RightSizing(configId int64, readings []conf.Reading, from time.Time, to time.Time, targetFill float64) apiserver.RightSizingReport {
+	if targetFill <= 0 {
+		targetFill = DefaultTargetFill
+	}
+	report := apiserver.RightSizingReport{
+		ConfigId:   configId,
+		From:       from,
+		To:         to,
+		TargetFill: targetFill,
+		Bins:       []apiserver.RightSizing{},
+	}
+	for _, deviceReadings := range readingsByDevice(readings) {
+		bin := binRightSizing(deviceReadings, targetFill)
+		report.TripsSavedPerMonth += bin.TripsSavedPerMonth
+		report.Bins = append(report.Bins, bin)
+	}
+	report.TripsSavedPerMonth = round(report.TripsSavedPerMonth, 2)
+	sort.Slice(report.Bins, func(i, j int) bool { return report.Bins[i].DeviceId < report.Bins[j].DeviceId })
+	return report
+}
+
+// binRightSizing recommends a change for the bin from its readings ordered by timestamp
+func binRightSizing(readings []conf.Reading, targetFill float64) apiserver.RightSizing {
+	last := readings[len(readings)-1]
+	bin := apiserver.RightSizing{
+		DeviceId:       last.DeviceId,
+		BinVolume:      last.BinVolume,
+		Recommendation: RecommendInsufficientData,
+	}
+	if last.StationId != "" {
+		bin.StationId = common.Ptr(last.StationId)
+	}
+	if last.ContentCategory != "" {
+		bin.ContentCategory = common.Ptr(last.ContentCategory)
+	}
+	history := fillHistoryOf(readings)
+	bin.Days = round(history.days, 2)
+	if history.fillsAtEmptying > 0 {
+		bin.AvgFillAtEmptying = common.Ptr(round(history.fillAtEmptying/float64(history.fillsAtEmptying), 1))
+	}
+	if history.days < minObservationDays || history.emptyings == 0 {
+		return bin
+	}
+
+	fillRate := history.filled / history.days
+	bin.FillRate = common.Ptr(round(fillRate, 2))
+	if bin.BinVolume != nil {
+		bin.LitersPerDay = common.Ptr(round(fillRate/100*float64(*bin.BinVolume), 2))
+	}
+	observed := float64(history.emptyings) / history.days * daysPerMonth
+	bin.EmptyingsPerMonth = round(observed, 2)
+
+	// needed returns the emptyings per month to reach the target fill level with a bin of the volume
+	needed := func(volume int32) float64 {
+		return fillRate * float64(*bin.BinVolume) / float64(volume) * daysPerMonth / targetFill
+	}
+	baseline, planned := observed, observed
+	neededNow := fillRate * daysPerMonth / targetFill
+	switch {
+	case neededNow > observed*(1+tolerance):
+		if volume, ok := largerVolume(bin.BinVolume, func(volume int32) bool { return needed(volume) <= observed }); ok {
+			bin.Recommendation = RecommendIncreaseVolume
+			bin.RecommendedVolume = common.Ptr(volume)
+			baseline = neededNow
+		} else {
+			bin.Recommendation = RecommendIncreaseFrequency
+			planned = neededNow
+		}
+	case neededNow < observed*(1-tolerance):
+		if reduced := math.Max(neededNow, minEmptyingsPerMonth); reduced < observed*(1-tolerance) {
+			bin.Recommendation = RecommendReduceFrequency
+			planned = reduced
+		} else if volume, ok := smallerVolume(bin.BinVolume, func(volume int32) bool { return needed(volume) <= observed }); ok {
+			bin.Recommendation = RecommendDecreaseVolume
+			bin.RecommendedVolume = common.Ptr(volume)
+		} else {
+			bin.Recommendation = RecommendKeep
+		}
+	default:
+		bin.Recommendation = RecommendKeep
+	}
+	if bin.Recommendation != RecommendKeep {
+		bin.RecommendedEmptyingsPerMonth = common.Ptr(round(planned, 2))
+	}
+	bin.TripsSavedPerMonth = round(baseline-planned, 2)
+	return bin
+}
+
+// fillHistoryOf sums up the fill level increases and emptyings of the readings of a bin ordered by timestamp. After an
+// emptying the whole fill level of the reading is counted as increase.
+func fillHistoryOf(readings []conf.Reading) fillHistory {
+	var history fillHistory
+	history.days = readings[len(readings)-1].Timestamp.Sub(readings[0].Timestamp).Hours() / 24
+	for i := 1; i < len(readings); i++ {
+		previous, reading := readings[i-1], readings[i]
+		emptied := isEmptying(previous, reading)
+		if emptied {
+			history.emptyings++
+			if previous.Volumepercent != nil {
+				history.fillAtEmptying += *previous.Volumepercent
+				history.fillsAtEmptying++
+			}
+		}
+		if reading.Volumepercent == nil {
+			continue
+		}
+		if emptied {
+			history.filled += *reading.Volumepercent
+		} else if previous.Volumepercent != nil && *reading.Volumepercent > *previous.Volumepercent {
+			history.filled += *reading.Volumepercent - *previous.Volumepercent
+		}
+	}
+	return history
+}
+
+// largerVolume returns the smallest standard volume larger than the volume which is sufficient
+func largerVolume(volume *int32, sufficient func(int32) bool) (int32, bool) {
+	if volume == nil {
+		return 0, false
+	}
+	for _, standard := range StandardVolumes {
+		if standard > *volume && sufficient(standard) {
+			return standard, true
+		}
+	}
+	return 0, false
+}
+
+// smallerVolume returns the smallest standard volume smaller than the volume which is sufficient
+func smallerVolume(volume *int32, sufficient func(int32) bool) (int32, bool) {
+	if volume == nil {
+		return 0, false
+	}
+	for _, standard := range StandardVolumes {
+		if standard < *volume && sufficient(standard) {
+			return standard, true
+		}
+	}
+	return 0, false
+}
+
+// RightSizingCsv returns the recommendations of the report as CSV with one line for each bin
+func RightSizingCsv(report apiserver.RightSizingReport) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	err := writer.Write([]string{"device_id", "station_id", "content_category", "bin_volume", "days", "fill_rate",
+		"liters_per_day", "emptyings_per_month", "avg_fill_at_emptying", "recommendation", "recommended_volume",
+		"recommended_emptyings_per_month", "trips_saved_per_month"})
+	if err != nil {
+		return nil, err
+	}
+	for _, bin := range report.Bins {
+		err = writer.Write([]string{
+			bin.DeviceId,
+			common.Val(bin.StationId),
+			common.Val(bin.ContentCategory),
+			csvNumber(bin.BinVolume),
+			formatNumber(bin.Days),
+			csvNumber(bin.FillRate),
+			csvNumber(bin.LitersPerDay),
+			formatNumber(bin.EmptyingsPerMonth),
+			csvNumber(bin.AvgFillAtEmptying),
+			bin.Recommendation,
+			csvNumber(bin.RecommendedVolume),
+			csvNumber(bin.RecommendedEmptyingsPerMonth),
+			formatNumber(bin.TripsSavedPerMonth),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
+
+// csvNumber formats the optional number for CSV, empty if not defined
+func csvNumber[T int32 | float64](number *T) string {
+	if number == nil {
+		return ""
+	}
+	return strconv.FormatFloat(float64(*number), 'f', -1, 64)
+}
@@ -0,0 +1,83 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package analytics
+
+import (
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"hailo/conf"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRightSizing(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	// daily readings of bins filling up by the rate and emptied every period of days
+	bins := []struct {
+		deviceId string
+		volume   *int32
+		days     int
+		rate     float64
+		period   int
+	}{
+		{"full", common.Ptr[int32](120), 14, 25, 5},
+		{"large", common.Ptr[int32](240), 14, 2, 10},
+		{"short", nil, 3, 25, 2},
+		{"slow", nil, 14, 5, 5},
+	}
+	var readings []conf.Reading
+	for _, bin := range bins {
+		for day := 0; day <= bin.days; day++ {
+			level := bin.rate * float64(day%bin.period)
+			readings = append(readings, conf.Reading{DeviceId: bin.deviceId, BinVolume: bin.volume, Timestamp: from.AddDate(0, 0, day),
+				Volumepercent: common.Ptr(level), Openings: common.Ptr(int32(level))})
+		}
+	}
+
+	report := RightSizing(1, readings, from, from.AddDate(0, 0, 14), 0)
+	assert.Equal(t, DefaultTargetFill, report.TargetFill)
+	assert.Len(t, report.Bins, 4)
+
+	full := report.Bins[0]
+	assert.Equal(t, RecommendIncreaseVolume, full.Recommendation)
+	assert.Equal(t, int32(240), *full.RecommendedVolume)
+	assert.Equal(t, 4.29, full.EmptyingsPerMonth)
+	assert.Equal(t, 25.71, *full.LitersPerDay)
+	assert.Equal(t, 3.75, full.TripsSavedPerMonth)
+
+	large := report.Bins[1]
+	assert.Equal(t, RecommendDecreaseVolume, large.Recommendation)
+	assert.Equal(t, int32(80), *large.RecommendedVolume)
+	assert.Equal(t, 0.0, large.TripsSavedPerMonth)
+
+	assert.Equal(t, RecommendInsufficientData, report.Bins[2].Recommendation)
+	assert.Nil(t, report.Bins[2].FillRate)
+
+	slow := report.Bins[3]
+	assert.Equal(t, RecommendReduceFrequency, slow.Recommendation)
+	assert.Equal(t, minEmptyingsPerMonth, *slow.RecommendedEmptyingsPerMonth)
+	assert.Equal(t, 20.0, *slow.AvgFillAtEmptying)
+	assert.Nil(t, slow.RecommendedVolume)
+	assert.Equal(t, 2.29, slow.TripsSavedPerMonth)
+	assert.Equal(t, 6.04, report.TripsSavedPerMonth)
+
+	csv, err := RightSizingCsv(report)
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(csv)), "\n")
+	assert.Len(t, lines, 5)
+	assert.Equal(t, "full,,,120,14,21.43,25.71,4.29,100,increase_volume,240,4.29,3.75", lines[1])
+}
@@ -0,0 +1,264 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package analytics
+
+import (
+	"bytes"
+	"encoding/csv"
+	"hailo/apiserver"
+	"hailo/conf"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+// overflowLevel is the fill level in percent from which a bin overflows
+const overflowLevel = 100.0
+
+// prematureLevel is the fill level in percent below which an emptying is premature
+const prematureLevel = 50.0
+
+// DefaultThresholds are the fill levels in percent reported, if no thresholds are requested
+var DefaultThresholds = []float64{80, 100}
+
+// serviceLevel sums up the service level of one or more bins
+type serviceLevel struct {
+	bins               int
+	timeAbove          []time.Duration
+	overflowEpisodes   int
+	emptyings          int
+	fillAtEmptying     float64
+	fillsAtEmptying    int
+	prematureEmptyings int
+}
+
+// ServiceLevels reports the service levels of the bins from the readings of the date range. The readings have to be
+// ordered by device and timestamp. Each reading is valid until the next reading of the bin or the end of the range.
+// The last reading of each bin before the range is valid from the start of the range until the first reading in the
+// range. An overflow already open at the start of the range by the last reading before the range is not counted as
+// overflow episode, but its hours above the thresholds are counted from the start of the range.
+func ServiceLevels(configId int64, previous []conf.Reading, readings []conf.Reading, from time.Time, to time.Time, thresholds []float64) apiserver.ServiceLevelReport {
+	if len(thresholds) == 0 {
+		thresholds = DefaultThresholds
+	}
+	end := to
+	if now := time.Now(); end.After(now) {
+		end = now
+	}
+
+	bins := make(map[string]*serviceLevel)
+	stations := make(map[string]*serviceLevel)
+	categories := make(map[string]*serviceLevel)
+	started := make(map[string]bool)
+	for _, reading := range previous {
+		started[reading.DeviceId] = true
+	}
+	for _, deviceReadings := range readingsByDevice(withStartReadings(previous, readings, from)) {
+		level := binServiceLevel(deviceReadings, started[deviceReadings[0].DeviceId], end, thresholds)
+		last := deviceReadings[len(deviceReadings)-1]
+		bins[last.DeviceId] = level
+		if last.StationId != "" {
+			add(stations, last.StationId, level)
+		}
+		if last.ContentCategory != "" {
+			add(categories, last.ContentCategory, level)
+		}
+	}
+
+	return apiserver.ServiceLevelReport{
+		ConfigId:   configId,
+		From:       from,
+		To:         to,
+		Thresholds: thresholds,
+		Bins:       apiServiceLevels(bins),
+		Stations:   apiServiceLevels(stations),
+		Categories: apiServiceLevels(categories),
+	}
+}
+
+// readingsByDevice splits the readings ordered by device into the readings of each device
+func readingsByDevice(readings []conf.Reading) [][]conf.Reading {
+	var devices [][]conf.Reading
+	for i, reading := range readings {
+		if i == 0 || reading.DeviceId != readings[i-1].DeviceId {
+			devices = append(devices, nil)
+		}
+		devices[len(devices)-1] = append(devices[len(devices)-1], reading)
+	}
+	return devices
+}
+
+// withStartReadings adds the last reading of each bin before the range as reading at the start of the range, so the
+// time from the start of the range until the first reading in the range is counted. The result is ordered by device
+// and timestamp like the readings.
+func withStartReadings(previous []conf.Reading, readings []conf.Reading, from time.Time) []conf.Reading {
+	combined := make([]conf.Reading, 0, len(previous)+len(readings))
+	for _, reading := range previous {
+		reading.Timestamp = from
+		combined = append(combined, reading)
+	}
+	combined = append(combined, readings...)
+	sort.SliceStable(combined, func(i, j int) bool { return combined[i].DeviceId < combined[j].DeviceId })
+	return combined
+}
+
+// binServiceLevel computes the service level of a bin from its readings ordered by timestamp. If the first reading is
+// the last reading before the range, an overflow of this reading is already open and not counted as new episode.
+func binServiceLevel(readings []conf.Reading, started bool, end time.Time, thresholds []float64) *serviceLevel {
+	level := &serviceLevel{bins: 1, timeAbove: make([]time.Duration, len(thresholds))}
+	for i, reading := range readings {
+		until := end
+		if i+1 < len(readings) {
+			until = readings[i+1].Timestamp
+		}
+		if reading.Volumepercent != nil && until.After(reading.Timestamp) {
+			for j, threshold := range thresholds {
+				if *reading.Volumepercent >= threshold {
+					level.timeAbove[j] += until.Sub(reading.Timestamp)
+				}
+			}
+		}
+		overflows := reading.Volumepercent != nil && *reading.Volumepercent >= overflowLevel
+		if i == 0 {
+			if overflows && !started {
+				level.overflowEpisodes++
+			}
+			continue
+		}
+		previous := readings[i-1]
+		if overflows && (previous.Volumepercent == nil || *previous.Volumepercent < overflowLevel) {
+			level.overflowEpisodes++
+		}
+		if isEmptying(previous, reading) {
+			level.emptyings++
+			if previous.Volumepercent != nil {
+				level.fillAtEmptying += *previous.Volumepercent
+				level.fillsAtEmptying++
+				if *previous.Volumepercent < prematureLevel {
+					level.prematureEmptyings++
+				}
+			}
+		}
+	}
+	return level
+}
+
+// isEmptying returns true, if the bin was emptied between the readings. A bin is emptied, if the last service
+// changed or the openings since the last emptying decreased.
+func isEmptying(previous conf.Reading, reading conf.Reading) bool {
+	if reading.LastService != nil && reading.LastService.After(previous.Timestamp) &&
+		(previous.LastService == nil || reading.LastService.After(*previous.LastService)) {
+		return true
+	}
+	return previous.Openings != nil && reading.Openings != nil && *reading.Openings < *previous.Openings
+}
+
+// add adds the service level of a bin to the aggregated service level with the id
+func add(levels map[string]*serviceLevel, id string, bin *serviceLevel) {
+	level, found := levels[id]
+	if !found {
+		level = &serviceLevel{timeAbove: make([]time.Duration, len(bin.timeAbove))}
+		levels[id] = level
+	}
+	level.bins += bin.bins
+	for i := range bin.timeAbove {
+		level.timeAbove[i] += bin.timeAbove[i]
+	}
+	level.overflowEpisodes += bin.overflowEpisodes
+	level.emptyings += bin.emptyings
+	level.fillAtEmptying += bin.fillAtEmptying
+	level.fillsAtEmptying += bin.fillsAtEmptying
+	level.prematureEmptyings += bin.prematureEmptyings
+}
+
+// apiServiceLevels converts the service levels to the API sorted by id
+func apiServiceLevels(levels map[string]*serviceLevel) []apiserver.ServiceLevel {
+	apiLevels := []apiserver.ServiceLevel{}
+	for id, level := range levels {
+		apiLevel := apiserver.ServiceLevel{
+			Id:                 id,
+			Bins:               int32(level.bins),
+			OverflowEpisodes:   int32(level.overflowEpisodes),
+			Emptyings:          int32(level.emptyings),
+			PrematureEmptyings: int32(level.prematureEmptyings),
+		}
+		for _, timeAbove := range level.timeAbove {
+			apiLevel.HoursAbove = append(apiLevel.HoursAbove, round(timeAbove.Hours(), 2))
+		}
+		if level.fillsAtEmptying > 0 {
+			apiLevel.AvgFillAtEmptying = common.Ptr(round(level.fillAtEmptying/float64(level.fillsAtEmptying), 1))
+		}
+		apiLevels = append(apiLevels, apiLevel)
+	}
+	sort.Slice(apiLevels, func(i, j int) bool { return apiLevels[i].Id < apiLevels[j].Id })
+	return apiLevels
+}
+
+// ServiceLevelsCsv returns the service levels of the report as CSV with one line for each bin, station and content
+// category
+func ServiceLevelsCsv(report apiserver.ServiceLevelReport) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	header := []string{"level", "id", "bins"}
+	for _, threshold := range report.Thresholds {
+		header = append(header, "hours_above_"+formatNumber(threshold))
+	}
+	header = append(header, "overflow_episodes", "emptyings", "avg_fill_at_emptying", "premature_emptyings")
+	err := writer.Write(header)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range []struct {
+		name   string
+		levels []apiserver.ServiceLevel
+	}{{"bin", report.Bins}, {"station", report.Stations}, {"category", report.Categories}} {
+		for _, level := range group.levels {
+			record := []string{group.name, level.Id, strconv.Itoa(int(level.Bins))}
+			for _, hours := range level.HoursAbove {
+				record = append(record, formatNumber(hours))
+			}
+			record = append(record,
+				strconv.Itoa(int(level.OverflowEpisodes)),
+				strconv.Itoa(int(level.Emptyings)),
+				"",
+				strconv.Itoa(int(level.PrematureEmptyings)),
+			)
+			if level.AvgFillAtEmptying != nil {
+				record[len(record)-2] = formatNumber(*level.AvgFillAtEmptying)
+			}
+			err = writer.Write(record)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
+
+// formatNumber formats the number without unnecessary decimal places
+func formatNumber(number float64) string {
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+// round rounds the number to the decimal places
+func round(number float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(number*factor) / factor
+}
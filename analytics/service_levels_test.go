@@ -0,0 +1,104 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package analytics
+
+import (
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"hailo/conf"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServiceLevels(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(10 * time.Hour)
+	readings := []conf.Reading{
+		{DeviceId: "a", StationId: "s", ContentCategory: "paper", Timestamp: from, Volumepercent: common.Ptr(70.0), Openings: common.Ptr[int32](10)},
+		{DeviceId: "a", StationId: "s", ContentCategory: "paper", Timestamp: from.Add(2 * time.Hour), Volumepercent: common.Ptr(100.0), Openings: common.Ptr[int32](20)},
+		{DeviceId: "a", StationId: "s", ContentCategory: "paper", Timestamp: from.Add(3 * time.Hour), Volumepercent: common.Ptr(10.0), Openings: common.Ptr[int32](0)},
+		{DeviceId: "a", StationId: "s", ContentCategory: "paper", Timestamp: from.Add(5 * time.Hour), Volumepercent: common.Ptr(40.0), Openings: common.Ptr[int32](5)},
+		{DeviceId: "a", StationId: "s", ContentCategory: "paper", Timestamp: from.Add(6 * time.Hour), Volumepercent: common.Ptr(5.0), Openings: common.Ptr[int32](0)},
+		{DeviceId: "a", StationId: "s", ContentCategory: "paper", Timestamp: from.Add(8 * time.Hour), Volumepercent: common.Ptr(100.0), Openings: common.Ptr[int32](30)},
+		{DeviceId: "b", StationId: "s", ContentCategory: "paper", Timestamp: from, Volumepercent: common.Ptr(100.0), Openings: common.Ptr[int32](1)},
+	}
+
+	report := ServiceLevels(1, nil, readings, from, to, nil)
+	assert.Equal(t, DefaultThresholds, report.Thresholds)
+	assert.Len(t, report.Bins, 2)
+
+	a := report.Bins[0]
+	assert.Equal(t, "a", a.Id)
+	assert.Equal(t, []float64{3, 3}, a.HoursAbove)
+	assert.Equal(t, int32(2), a.OverflowEpisodes)
+	assert.Equal(t, int32(2), a.Emptyings)
+	assert.Equal(t, 70.0, *a.AvgFillAtEmptying)
+	assert.Equal(t, int32(1), a.PrematureEmptyings)
+
+	b := report.Bins[1]
+	assert.Equal(t, []float64{10, 10}, b.HoursAbove)
+	assert.Equal(t, int32(1), b.OverflowEpisodes)
+	assert.Nil(t, b.AvgFillAtEmptying)
+
+	assert.Len(t, report.Stations, 1)
+	assert.Equal(t, int32(2), report.Stations[0].Bins)
+	assert.Equal(t, []float64{13, 13}, report.Stations[0].HoursAbove)
+	assert.Equal(t, int32(3), report.Stations[0].OverflowEpisodes)
+	assert.Equal(t, "paper", report.Categories[0].Id)
+
+	csv, err := ServiceLevelsCsv(report)
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(csv)), "\n")
+	assert.Equal(t, "level,id,bins,hours_above_80,hours_above_100,overflow_episodes,emptyings,avg_fill_at_emptying,premature_emptyings", lines[0])
+	assert.Equal(t, "bin,a,1,3,3,2,2,70,1", lines[1])
+	assert.Equal(t, "bin,b,1,10,10,1,0,,0", lines[2])
+	assert.Len(t, lines, 5)
+}
+
+func TestServiceLevelsFromStart(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(10 * time.Hour)
+	previous := []conf.Reading{
+		{DeviceId: "full", Timestamp: from.Add(-48 * time.Hour), Volumepercent: common.Ptr(90.0), Openings: common.Ptr[int32](40)},
+		{DeviceId: "idle", Timestamp: from.Add(-time.Hour), Volumepercent: common.Ptr(85.0)},
+		{DeviceId: "overflowing", Timestamp: from.Add(-time.Hour), Volumepercent: common.Ptr(100.0)},
+	}
+	readings := []conf.Reading{
+		{DeviceId: "full", Timestamp: from.Add(4 * time.Hour), Volumepercent: common.Ptr(95.0), Openings: common.Ptr[int32](45)},
+		{DeviceId: "full", Timestamp: from.Add(6 * time.Hour), Volumepercent: common.Ptr(5.0), Openings: common.Ptr[int32](0)},
+	}
+
+	report := ServiceLevels(1, previous, readings, from, to, []float64{80})
+	assert.Len(t, report.Bins, 3)
+	assert.Equal(t, "full", report.Bins[0].Id)
+	assert.Equal(t, []float64{6}, report.Bins[0].HoursAbove)
+	assert.Equal(t, int32(1), report.Bins[0].Emptyings)
+	assert.Equal(t, "idle", report.Bins[1].Id)
+	assert.Equal(t, []float64{10}, report.Bins[1].HoursAbove)
+
+	// an overflow open at the start of the range is no new episode
+	assert.Equal(t, "overflowing", report.Bins[2].Id)
+	assert.Equal(t, []float64{10}, report.Bins[2].HoursAbove)
+	assert.Equal(t, int32(0), report.Bins[2].OverflowEpisodes)
+}
+
+func TestIsEmptying(t *testing.T) {
+	at := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	previous := conf.Reading{Timestamp: at, LastService: common.Ptr(at.Add(-time.Hour))}
+	assert.False(t, isEmptying(previous, conf.Reading{Timestamp: at.Add(time.Hour), LastService: previous.LastService}))
+	assert.True(t, isEmptying(previous, conf.Reading{Timestamp: at.Add(time.Hour), LastService: common.Ptr(at.Add(time.Minute))}))
+}
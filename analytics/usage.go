@@ -0,0 +1,170 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package analytics
+
+import (
+	"bytes"
+	"encoding/csv"
+	"hailo/apiserver"
+	"hailo/conf"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+// maxSpreadGap is the maximum time between two readings whose openings are spread over the hours between them.
+// Openings of longer gaps, e.g. after an outage, can't be attributed to hours.
+const maxSpreadGap = 6 * time.Hour
+
+// Weekdays are the names of the weekdays in the order of the usage heatmap
+var Weekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// SpreadOpenings spreads the openings between two readings over the hours between them proportional to the time
+// within each hour. The hours are returned with their share of the openings. If the readings are more than the
+// maximum gap apart, nil is returned.
+func SpreadOpenings(from time.Time, to time.Time, openings int32) map[time.Time]int32 {
+	if to.Sub(from) > maxSpreadGap {
+		return nil
+	}
+	if !to.After(from) {
+		return map[time.Time]int32{to.Truncate(time.Hour): openings}
+	}
+	type share struct {
+		hour      time.Time
+		openings  int32
+		remainder float64
+	}
+	var shares []share
+	spread := int32(0)
+	for hour := from.Truncate(time.Hour); hour.Before(to); hour = hour.Add(time.Hour) {
+		start, end := hour, hour.Add(time.Hour)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		exact := float64(openings) * float64(end.Sub(start)) / float64(to.Sub(from))
+		shares = append(shares, share{hour: hour, openings: int32(exact), remainder: exact - float64(int32(exact))})
+		spread += int32(exact)
+	}
+	// the openings lost by rounding down go to the hours with the largest remainders
+	sort.SliceStable(shares, func(i, j int) bool { return shares[i].remainder > shares[j].remainder })
+	for i := 0; spread < openings; i++ {
+		shares[i%len(shares)].openings++
+		spread++
+	}
+	hours := make(map[time.Time]int32)
+	for _, share := range shares {
+		if share.openings > 0 {
+			hours[share.hour] = share.openings
+		}
+	}
+	return hours
+}
+
+// UsagePatterns sums up the hourly usages of each device per weekday and hour of the day in the location. The usages
+// have to be ordered by device.
+func UsagePatterns(configId int64, usages []conf.Usage, from time.Time, to time.Time, location *time.Location) apiserver.UsageReport {
+	report := apiserver.UsageReport{
+		ConfigId: configId,
+		From:     from,
+		To:       to,
+		TimeZone: location.String(),
+		Devices:  []apiserver.UsagePattern{},
+	}
+	for i, usage := range usages {
+		if i == 0 || usage.DeviceId != usages[i-1].DeviceId {
+			report.Devices = append(report.Devices, newUsagePattern(usage))
+		}
+		pattern := &report.Devices[len(report.Devices)-1]
+		hour := usage.Hour.In(location)
+		weekday := (int(hour.Weekday()) + 6) % 7
+		pattern.Heatmap[weekday][hour.Hour()] += usage.Openings
+		pattern.OpeningsByWeekday[weekday] += usage.Openings
+		pattern.OpeningsByHour[hour.Hour()] += usage.Openings
+		pattern.Openings += usage.Openings
+	}
+	for i := range report.Devices {
+		pattern := &report.Devices[i]
+		if pattern.Openings == 0 {
+			continue
+		}
+		pattern.PeakHour = common.Ptr(int32(maxIndex(pattern.OpeningsByHour)))
+		pattern.PeakWeekday = common.Ptr(Weekdays[maxIndex(pattern.OpeningsByWeekday)])
+	}
+	return report
+}
+
+// newUsagePattern returns an empty usage pattern for the device of the usage
+func newUsagePattern(usage conf.Usage) apiserver.UsagePattern {
+	pattern := apiserver.UsagePattern{
+		DeviceId:          usage.DeviceId,
+		Kind:              usage.Kind,
+		Heatmap:           make([][]int32, len(Weekdays)),
+		OpeningsByHour:    make([]int32, 24),
+		OpeningsByWeekday: make([]int32, len(Weekdays)),
+	}
+	for i := range pattern.Heatmap {
+		pattern.Heatmap[i] = make([]int32, 24)
+	}
+	if usage.StationId != "" {
+		pattern.StationId = common.Ptr(usage.StationId)
+	}
+	return pattern
+}
+
+// maxIndex returns the index of the first maximum of the values
+func maxIndex(values []int32) int {
+	index := 0
+	for i, value := range values {
+		if value > values[index] {
+			index = i
+		}
+	}
+	return index
+}
+
+// UsagePatternsCsv returns the heatmaps of the report as CSV with one line for each device, weekday and hour
+func UsagePatternsCsv(report apiserver.UsageReport) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	err := writer.Write([]string{"device_id", "kind", "station_id", "weekday", "hour", "openings"})
+	if err != nil {
+		return nil, err
+	}
+	for _, pattern := range report.Devices {
+		for weekday, hours := range pattern.Heatmap {
+			for hour, openings := range hours {
+				err = writer.Write([]string{
+					pattern.DeviceId,
+					pattern.Kind,
+					common.Val(pattern.StationId),
+					Weekdays[weekday],
+					strconv.Itoa(hour),
+					strconv.Itoa(int(openings)),
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
@@ -0,0 +1,99 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package analytics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"hailo/conf"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSpreadOpenings(t *testing.T) {
+	start := time.Date(2023, 1, 2, 10, 30, 0, 0, time.UTC)
+	ten := time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC)
+	eleven := time.Date(2023, 1, 2, 11, 0, 0, 0, time.UTC)
+	twelve := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	// within one hour
+	assert.Equal(t, map[time.Time]int32{ten: 5}, SpreadOpenings(start, start.Add(20*time.Minute), 5))
+
+	// half an hour in 10, one hour in 11 and half an hour in 12
+	assert.Equal(t, map[time.Time]int32{ten: 2, eleven: 4, twelve: 2}, SpreadOpenings(start, start.Add(2*time.Hour), 8))
+
+	// rounded down shares are filled up to the total
+	spread := SpreadOpenings(start, start.Add(3*time.Hour), 7)
+	total := int32(0)
+	for _, openings := range spread {
+		total += openings
+	}
+	assert.Equal(t, int32(7), total)
+
+	// same time
+	assert.Equal(t, map[time.Time]int32{ten: 3}, SpreadOpenings(start, start, 3))
+
+	// gap too long
+	assert.Nil(t, SpreadOpenings(start, start.Add(7*time.Hour), 10))
+}
+
+func TestUsagePatterns(t *testing.T) {
+	// monday 2023-01-02
+	monday := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	usages := []conf.Usage{
+		{DeviceId: "0815", Kind: "bin", StationId: "4711", Hour: monday.Add(8 * time.Hour), Openings: 3},
+		{DeviceId: "0815", Kind: "bin", StationId: "4711", Hour: monday.Add(23 * time.Hour), Openings: 10},
+		{DeviceId: "0815", Kind: "bin", StationId: "4711", Hour: monday.AddDate(0, 0, 7).Add(8 * time.Hour), Openings: 4},
+		{DeviceId: "0816", Kind: "bin", StationId: "4711", Hour: monday.Add(12 * time.Hour), Openings: 0},
+	}
+
+	report := UsagePatterns(1, usages, monday, monday.AddDate(0, 0, 14), time.UTC)
+	assert.Equal(t, "UTC", report.TimeZone)
+	assert.Equal(t, 2, len(report.Devices))
+	pattern := report.Devices[0]
+	assert.Equal(t, "0815", pattern.DeviceId)
+	assert.Equal(t, "4711", *pattern.StationId)
+	assert.Equal(t, int32(17), pattern.Openings)
+	assert.Equal(t, int32(7), pattern.Heatmap[0][8])
+	assert.Equal(t, int32(10), pattern.Heatmap[0][23])
+	assert.Equal(t, int32(17), pattern.OpeningsByWeekday[0])
+	assert.Equal(t, int32(23), *pattern.PeakHour)
+	assert.Equal(t, "monday", *pattern.PeakWeekday)
+	assert.Nil(t, report.Devices[1].PeakHour)
+	assert.Nil(t, report.Devices[1].PeakWeekday)
+
+	// in Zurich the 23:00 UTC is midnight of tuesday
+	zurich, err := time.LoadLocation("Europe/Zurich")
+	assert.Nil(t, err)
+	report = UsagePatterns(1, usages, monday, monday.AddDate(0, 0, 14), zurich)
+	pattern = report.Devices[0]
+	assert.Equal(t, "Europe/Zurich", report.TimeZone)
+	assert.Equal(t, int32(7), pattern.Heatmap[0][9])
+	assert.Equal(t, int32(10), pattern.Heatmap[1][0])
+	assert.Equal(t, int32(0), *pattern.PeakHour)
+	assert.Equal(t, "tuesday", *pattern.PeakWeekday)
+}
+
+func TestUsagePatternsCsv(t *testing.T) {
+	monday := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	usages := []conf.Usage{{DeviceId: "0815", Kind: "station", Hour: monday.Add(8 * time.Hour), Openings: 3}}
+	content, err := UsagePatternsCsv(UsagePatterns(1, usages, monday, monday.AddDate(0, 0, 7), time.UTC))
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Equal(t, 1+7*24, len(lines))
+	assert.Equal(t, "device_id,kind,station_id,weekday,hour,openings", lines[0])
+	assert.Equal(t, "0815,station,,monday,8,3", lines[9])
+}
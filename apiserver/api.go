@@ -14,11 +14,25 @@ import (
 	"net/http"
 )
 
+// AnalyticsApiRouter defines the required methods for binding the api requests to a responses for the AnalyticsApi
+// The AnalyticsApiRouter implementation should parse necessary information from the http request,
+// pass the data to a AnalyticsApiServicer to perform the required actions, then write the service results to the http response.
+type AnalyticsApiRouter interface {
+	GetBatteryReplacements(http.ResponseWriter, *http.Request)
+	GetEmptyingRoute(http.ResponseWriter, *http.Request)
+	GetGeoJson(http.ResponseWriter, *http.Request)
+	GetRightSizing(http.ResponseWriter, *http.Request)
+	GetSensorAnomalies(http.ResponseWriter, *http.Request)
+	GetServiceLevels(http.ResponseWriter, *http.Request)
+	GetUsage(http.ResponseWriter, *http.Request)
+}
+
 // AssetMappingApiRouter defines the required methods for binding the api requests to a responses for the AssetMappingApi
 // The AssetMappingApiRouter implementation should parse necessary information from the http request,
 // pass the data to a AssetMappingApiServicer to perform the required actions, then write the service results to the http response.
 type AssetMappingApiRouter interface {
 	GetAssetMappings(http.ResponseWriter, *http.Request)
+	PutAssetMappingLocation(http.ResponseWriter, *http.Request)
 }
 
 // ConfigurationApiRouter defines the required methods for binding the api requests to a responses for the ConfigurationApi
@@ -47,12 +61,27 @@ type VersionApiRouter interface {
 	GetVersion(http.ResponseWriter, *http.Request)
 }
 
+// AnalyticsApiServicer defines the api actions for the AnalyticsApi service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type AnalyticsApiServicer interface {
+	GetBatteryReplacements(context.Context, int64, int32, string) (ImplResponse, error)
+	GetEmptyingRoute(context.Context, int64, float64, string, *float64, *float64, string) (ImplResponse, error)
+	GetGeoJson(context.Context, int64) (ImplResponse, error)
+	GetRightSizing(context.Context, int64, string, string, float64, string) (ImplResponse, error)
+	GetSensorAnomalies(context.Context, int64, string, string, string, string) (ImplResponse, error)
+	GetServiceLevels(context.Context, int64, string, string, []float64, string) (ImplResponse, error)
+	GetUsage(context.Context, int64, string, string, string, string, string) (ImplResponse, error)
+}
+
 // AssetMappingApiServicer defines the api actions for the AssetMappingApi service
 // This interface intended to stay up to date with the openapi yaml used to generate it,
 // while the service implementation can be ignored with the .openapi-generator-ignore file
 // and updated with the logic required for the API.
 type AssetMappingApiServicer interface {
 	GetAssetMappings(context.Context, int64) (ImplResponse, error)
+	PutAssetMappingLocation(context.Context, int64, string, Location) (ImplResponse, error)
 }
 
 // ConfigurationApiServicer defines the api actions for the ConfigurationApi service
@@ -72,7 +101,7 @@ type ConfigurationApiServicer interface {
 // while the service implementation can be ignored with the .openapi-generator-ignore file
 // and updated with the logic required for the API.
 type CustomizationApiServicer interface {
-	GetDashboardTemplateByName(context.Context, string, string) (ImplResponse, error)
+	GetDashboardTemplateByName(context.Context, string, string, int32, int32, string, int64) (ImplResponse, error)
 }
 
 // VersionApiServicer defines the api actions for the VersionApi service
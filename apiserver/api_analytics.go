@@ -0,0 +1,327 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// AnalyticsApiController binds http requests to an api service and writes the service results to the http response
+type AnalyticsApiController struct {
+	service      AnalyticsApiServicer
+	errorHandler ErrorHandler
+}
+
+// AnalyticsApiOption for how the controller is set up.
+type AnalyticsApiOption func(*AnalyticsApiController)
+
+// WithAnalyticsApiErrorHandler inject ErrorHandler into controller
+func WithAnalyticsApiErrorHandler(h ErrorHandler) AnalyticsApiOption {
+	return func(c *AnalyticsApiController) {
+		c.errorHandler = h
+	}
+}
+
+// NewAnalyticsApiController creates a default api controller
+func NewAnalyticsApiController(s AnalyticsApiServicer, opts ...AnalyticsApiOption) Router {
+	controller := &AnalyticsApiController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the AnalyticsApiController
+func (c *AnalyticsApiController) Routes() Routes {
+	return Routes{
+		{
+			"GetGeoJson",
+			strings.ToUpper("Get"),
+			"/v1/configs/{config-id}/geojson",
+			c.GetGeoJson,
+		},
+		{
+			"GetEmptyingRoute",
+			strings.ToUpper("Get"),
+			"/v1/configs/{config-id}/route",
+			c.GetEmptyingRoute,
+		},
+		{
+			"GetServiceLevels",
+			strings.ToUpper("Get"),
+			"/v1/configs/{config-id}/service-levels",
+			c.GetServiceLevels,
+		},
+		{
+			"GetRightSizing",
+			strings.ToUpper("Get"),
+			"/v1/configs/{config-id}/right-sizing",
+			c.GetRightSizing,
+		},
+		{
+			"GetBatteryReplacements",
+			strings.ToUpper("Get"),
+			"/v1/configs/{config-id}/battery-replacements",
+			c.GetBatteryReplacements,
+		},
+		{
+			"GetSensorAnomalies",
+			strings.ToUpper("Get"),
+			"/v1/configs/{config-id}/anomalies",
+			c.GetSensorAnomalies,
+		},
+		{
+			"GetUsage",
+			strings.ToUpper("Get"),
+			"/v1/configs/{config-id}/usage",
+			c.GetUsage,
+		},
+	}
+}
+
+// GetGeoJson - Get bins as GeoJSON
+func (c *AnalyticsApiController) GetGeoJson(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	configIdParam, err := parseInt64Parameter(params["config-id"], true)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+
+	result, err := c.service.GetGeoJson(r.Context(), configIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+
+}
+
+// GetEmptyingRoute - Get the emptying route
+func (c *AnalyticsApiController) GetEmptyingRoute(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query := r.URL.Query()
+	configIdParam, err := parseInt64Parameter(params["config-id"], true)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+
+	thresholdParam, err := parseFloat64Parameter(query.Get("threshold"), false)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	orderParam := query.Get("order")
+	var latitudeParam *float64
+	if query.Has("latitude") {
+		latitude, err := parseFloat64Parameter(query.Get("latitude"), true)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+		latitudeParam = &latitude
+	}
+	var longitudeParam *float64
+	if query.Has("longitude") {
+		longitude, err := parseFloat64Parameter(query.Get("longitude"), true)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+		longitudeParam = &longitude
+	}
+	formatParam := query.Get("format")
+	result, err := c.service.GetEmptyingRoute(r.Context(), configIdParam, thresholdParam, orderParam, latitudeParam, longitudeParam, formatParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If the result is a file, write the file instead of JSON
+	if file, ok := result.Body.(FileResponse); ok {
+		EncodeFileResponse(file, &result.Code, w)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+
+}
+
+// GetServiceLevels - Get the service level report
+func (c *AnalyticsApiController) GetServiceLevels(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query := r.URL.Query()
+	configIdParam, err := parseInt64Parameter(params["config-id"], true)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+
+	fromParam := query.Get("from")
+	toParam := query.Get("to")
+	thresholdsParam, err := parseFloat64ArrayParameter(query.Get("thresholds"), ",", false)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	formatParam := query.Get("format")
+	result, err := c.service.GetServiceLevels(r.Context(), configIdParam, fromParam, toParam, thresholdsParam, formatParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If the result is a file, write the file instead of JSON
+	if file, ok := result.Body.(FileResponse); ok {
+		EncodeFileResponse(file, &result.Code, w)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+
+}
+
+// GetRightSizing - Get right-sizing recommendations
+func (c *AnalyticsApiController) GetRightSizing(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query := r.URL.Query()
+	configIdParam, err := parseInt64Parameter(params["config-id"], true)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+
+	fromParam := query.Get("from")
+	toParam := query.Get("to")
+	targetFillParam, err := parseFloat64Parameter(query.Get("targetFill"), false)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	formatParam := query.Get("format")
+	result, err := c.service.GetRightSizing(r.Context(), configIdParam, fromParam, toParam, targetFillParam, formatParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If the result is a file, write the file instead of JSON
+	if file, ok := result.Body.(FileResponse); ok {
+		EncodeFileResponse(file, &result.Code, w)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+
+}
+
+// GetBatteryReplacements - Get the battery replacement plan
+func (c *AnalyticsApiController) GetBatteryReplacements(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query := r.URL.Query()
+	configIdParam, err := parseInt64Parameter(params["config-id"], true)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+
+	daysParam, err := parseInt32Parameter(query.Get("days"), false)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	formatParam := query.Get("format")
+	result, err := c.service.GetBatteryReplacements(r.Context(), configIdParam, daysParam, formatParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If the result is a file, write the file instead of JSON
+	if file, ok := result.Body.(FileResponse); ok {
+		EncodeFileResponse(file, &result.Code, w)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+
+}
+
+// GetSensorAnomalies - Get sensor anomalies
+func (c *AnalyticsApiController) GetSensorAnomalies(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query := r.URL.Query()
+	configIdParam, err := parseInt64Parameter(params["config-id"], true)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+
+	fromParam := query.Get("from")
+	toParam := query.Get("to")
+	ruleParam := query.Get("rule")
+	formatParam := query.Get("format")
+	result, err := c.service.GetSensorAnomalies(r.Context(), configIdParam, fromParam, toParam, ruleParam, formatParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If the result is a file, write the file instead of JSON
+	if file, ok := result.Body.(FileResponse); ok {
+		EncodeFileResponse(file, &result.Code, w)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+
+}
+
+// GetUsage - Get usage heatmaps
+func (c *AnalyticsApiController) GetUsage(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query := r.URL.Query()
+	configIdParam, err := parseInt64Parameter(params["config-id"], true)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+
+	fromParam := query.Get("from")
+	toParam := query.Get("to")
+	kindParam := query.Get("kind")
+	timeZoneParam := query.Get("timeZone")
+	formatParam := query.Get("format")
+	result, err := c.service.GetUsage(r.Context(), configIdParam, fromParam, toParam, kindParam, timeZoneParam, formatParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If the result is a file, write the file instead of JSON
+	if file, ok := result.Body.(FileResponse); ok {
+		EncodeFileResponse(file, &result.Code, w)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+
+}
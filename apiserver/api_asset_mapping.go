@@ -10,8 +10,11 @@
 package apiserver
 
 import (
+	"encoding/json"
 	"net/http"
 	"strings"
+
+	"github.com/gorilla/mux"
 )
 
 // AssetMappingApiController binds http requests to an api service and writes the service results to the http response
@@ -53,6 +56,12 @@ func (c *AssetMappingApiController) Routes() Routes {
 			"/v1/asset-mappings",
 			c.GetAssetMappings,
 		},
+		{
+			"PutAssetMappingLocation",
+			strings.ToUpper("Put"),
+			"/v1/asset-mappings/{config-id}/{device-id}/location",
+			c.PutAssetMappingLocation,
+		},
 	}
 }
 
@@ -74,3 +83,36 @@ func (c *AssetMappingApiController) GetAssetMappings(w http.ResponseWriter, r *h
 	EncodeJSONResponse(result.Body, &result.Code, w)
 
 }
+
+// PutAssetMappingLocation - Overrides the location of a device
+func (c *AssetMappingApiController) PutAssetMappingLocation(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	configIdParam, err := parseInt64Parameter(params["config-id"], true)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+
+	deviceIdParam := params["device-id"]
+
+	locationParam := Location{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&locationParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertLocationRequired(locationParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.PutAssetMappingLocation(r.Context(), configIdParam, deviceIdParam, locationParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+
+}
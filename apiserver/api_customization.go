@@ -65,7 +65,23 @@ func (c *CustomizationApiController) GetDashboardTemplateByName(w http.ResponseW
 	dashboardTemplateNameParam := params["dashboard-template-name"]
 
 	projectIdParam := query.Get("projectId")
-	result, err := c.service.GetDashboardTemplateByName(r.Context(), dashboardTemplateNameParam, projectIdParam)
+	stationAssetIdParam, err := parseInt32Parameter(query.Get("stationAssetId"), false)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	hubAssetIdParam, err := parseInt32Parameter(query.Get("hubAssetId"), false)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	contentCategoryParam := query.Get("contentCategory")
+	configIdParam, err := parseInt64Parameter(query.Get("configId"), false)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.GetDashboardTemplateByName(r.Context(), dashboardTemplateNameParam, projectIdParam, stationAssetIdParam, hubAssetIdParam, contentCategoryParam, configIdParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -14,3 +14,10 @@ type ImplResponse struct {
 	Code int
 	Body interface{}
 }
+
+// FileResponse defines a body delivered as file instead of JSON, e.g. as CSV
+type FileResponse struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
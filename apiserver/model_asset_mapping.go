@@ -23,6 +23,15 @@ type AssetMapping struct {
 
 	// References the asset id in Eliona which is automatically created by the app
 	AssetId int32 `json:"assetId,omitempty"`
+
+	// Latitude of the device which overrides the location read from Hailo FDS
+	Latitude *float64 `json:"latitude,omitempty"`
+
+	// Longitude of the device which overrides the location read from Hailo FDS
+	Longitude *float64 `json:"longitude,omitempty"`
+
+	// Address of the device which overrides the address read from Hailo FDS
+	Address *string `json:"address,omitempty"`
 }
 
 // AssertAssetMappingRequired checks if the required fields are not zero-ed
@@ -0,0 +1,76 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// AttributeMapping - The `AttributeMapping` defines which value read from Hailo FDS is written to which attribute of an Eliona asset type. Numeric values can be scaled, rounded and checked against a valid range. Values outside the range are written as null.
+type AttributeMapping struct {
+
+	// Name of the Eliona asset type
+	AssetType string `json:"assetType,omitempty"`
+
+	// Name of the attribute defined in the asset type
+	Attribute string `json:"attribute,omitempty"`
+
+	// Subtype of the attribute defined in the asset type
+	Subtype string `json:"subtype,omitempty"`
+
+	// Source of the value, either the specification, status or diagnostic read from Hailo FDS or a value computed by the app
+	Source string `json:"source,omitempty"`
+
+	// Dot separated path to the value in the source. Array elements are referenced by their index.
+	Path string `json:"path,omitempty"`
+
+	// Factor the value is multiplied with, e.g. 100 to convert a ratio to a percentage
+	Scale *float64 `json:"scale,omitempty"`
+
+	// Number of decimal places the value is rounded to
+	Round *int32 `json:"round,omitempty"`
+
+	// Lowest valid value after scaling
+	Min *float64 `json:"min,omitempty"`
+
+	// Highest valid value after scaling
+	Max *float64 `json:"max,omitempty"`
+
+	// Converts a timestamp to the hours or days between the timestamp and now
+	Conversion *string `json:"conversion,omitempty"`
+
+	// Set to `true` to remove a default mapping
+	Disable *bool `json:"disable,omitempty"`
+
+	// Eliona type of an attribute not defined in the asset type, e.g. `device-info`. If set, the attribute is added to the asset type.
+	AttributeType *string `json:"attributeType,omitempty"`
+
+	// Physical unit of an attribute added to the asset type
+	Unit *string `json:"unit,omitempty"`
+
+	// Number of decimal places of an attribute added to the asset type
+	Precision *int32 `json:"precision,omitempty"`
+
+	// Display name of an attribute added to the asset type
+	Label *string `json:"label,omitempty"`
+}
+
+// AssertAttributeMappingRequired checks if the required fields are not zero-ed
+func AssertAttributeMappingRequired(obj AttributeMapping) error {
+	return nil
+}
+
+// AssertRecurseAttributeMappingRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of AttributeMapping (e.g. [][]AttributeMapping), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseAttributeMappingRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aAttributeMapping, ok := obj.(AttributeMapping)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertAttributeMappingRequired(aAttributeMapping)
+	})
+}
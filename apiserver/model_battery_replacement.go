@@ -0,0 +1,58 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// BatteryReplacement - Device whose battery has to be replaced
+type BatteryReplacement struct {
+
+	// Device id of the bin or station
+	DeviceId string `json:"deviceId"`
+
+	// Kind of the device
+	Kind string `json:"kind,omitempty"`
+
+	// Id of the Eliona asset of the device
+	AssetId *int32 `json:"assetId,omitempty"`
+
+	// Last battery level in percent
+	BatLevel float64 `json:"batLevel"`
+
+	// Forecasted days from now until the battery falls to the cut-off level
+	BatDaysLeft float64 `json:"batDaysLeft"`
+
+	// Date at which the battery falls to the cut-off level
+	ReplaceBy string `json:"replaceBy,omitempty"`
+}
+
+// AssertBatteryReplacementRequired checks if the required fields are not zero-ed
+func AssertBatteryReplacementRequired(obj BatteryReplacement) error {
+	elements := map[string]interface{}{
+		"deviceId": obj.DeviceId,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertRecurseBatteryReplacementRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of BatteryReplacement (e.g. [][]BatteryReplacement), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseBatteryReplacementRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aBatteryReplacement, ok := obj.(BatteryReplacement)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertBatteryReplacementRequired(aBatteryReplacement)
+	})
+}
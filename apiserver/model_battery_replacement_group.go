@@ -0,0 +1,48 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// BatteryReplacementGroup - Devices of a station or hub to replace the batteries
+type BatteryReplacementGroup struct {
+
+	// Device id of the station or hub, null for devices without station or hub
+	DeviceId *string `json:"deviceId,omitempty"`
+
+	// Kind of the device grouping the devices
+	Kind *string `json:"kind,omitempty"`
+
+	// Id of the Eliona asset of the station or hub
+	AssetId *int32 `json:"assetId,omitempty"`
+
+	// Devices to replace the batteries, the earliest replacement first
+	Devices []BatteryReplacement `json:"devices"`
+}
+
+// AssertBatteryReplacementGroupRequired checks if the required fields are not zero-ed
+func AssertBatteryReplacementGroupRequired(obj BatteryReplacementGroup) error {
+	for _, el := range obj.Devices {
+		if err := AssertBatteryReplacementRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertRecurseBatteryReplacementGroupRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of BatteryReplacementGroup (e.g. [][]BatteryReplacementGroup), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseBatteryReplacementGroupRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aBatteryReplacementGroup, ok := obj.(BatteryReplacementGroup)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertBatteryReplacementGroupRequired(aBatteryReplacementGroup)
+	})
+}
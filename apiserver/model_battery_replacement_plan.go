@@ -0,0 +1,48 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// BatteryReplacementPlan - Devices of a configuration whose batteries fall to the cut-off level within the planning period, grouped by station or hub
+type BatteryReplacementPlan struct {
+
+	// Id of the configuration the devices belong to
+	ConfigId int64 `json:"configId,omitempty"`
+
+	// Planning period in days from now
+	Days int32 `json:"days,omitempty"`
+
+	// Battery level in percent at which a battery has to be replaced
+	CutoffLevel float64 `json:"cutoffLevel,omitempty"`
+
+	// Devices to replace the batteries grouped by station or hub, the group with the earliest replacement first
+	Groups []BatteryReplacementGroup `json:"groups"`
+}
+
+// AssertBatteryReplacementPlanRequired checks if the required fields are not zero-ed
+func AssertBatteryReplacementPlanRequired(obj BatteryReplacementPlan) error {
+	for _, el := range obj.Groups {
+		if err := AssertBatteryReplacementGroupRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertRecurseBatteryReplacementPlanRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of BatteryReplacementPlan (e.g. [][]BatteryReplacementPlan), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseBatteryReplacementPlanRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aBatteryReplacementPlan, ok := obj.(BatteryReplacementPlan)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertBatteryReplacementPlanRequired(aBatteryReplacementPlan)
+	})
+}
@@ -48,15 +48,64 @@ type Configuration struct {
 	// Timeout for inactivity
 	InactiveTimeout int32 `json:"inactiveTimeout,omitempty"`
 
+	// Interval in seconds after which all data is written to Eliona again, even if the data has not changed since the last write
+	RefreshIntervalSec int32 `json:"refreshIntervalSec,omitempty"`
+
+	// Number of devices processed in parallel for this endpoint
+	Concurrency int32 `json:"concurrency,omitempty"`
+
+	// Maximum number of requests per second sent to the FDS host
+	RequestsPerSec int32 `json:"requestsPerSec,omitempty"`
+
+	// Maximum number of retries for failed FDS requests. Set to 0 to disable retries.
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+
+	// Delay in seconds before the first retry of a failed FDS request. The delay is doubled for each further retry.
+	RetryDelaySec int32 `json:"retryDelaySec,omitempty"`
+
+	// Number of consecutive failed FDS requests after which the circuit breaker opens and no further requests are sent
+	BreakerThreshold int32 `json:"breakerThreshold,omitempty"`
+
+	// Interval in seconds after which an open circuit breaker sends a probe request to check if the FDS endpoint is reachable again
+	BreakerProbeSec int32 `json:"breakerProbeSec,omitempty"`
+
 	// Set to `true` by the app when running and to `false` when app is stopped
 	Active *bool `json:"active,omitempty"`
 
+	// Set by the app to the state of the circuit breaker for the FDS endpoint (`closed`, `open` or `half-open`)
+	CircuitState *string `json:"circuitState,omitempty"`
+
 	// List of Eliona project ids for which this endpoint should collect data. For each project id all smart devices are automatically created as an asset in Eliona. The mapping between Eliona is stored as an asset mapping in the Hailo app and can read with the AssetMapping endpoint.
 	ProjIds *[]string `json:"projIds,omitempty"`
+
+	// Mappings of values read from Hailo FDS to asset attributes which override or extend the default mappings of the app. A mapping overrides the default mapping with the same asset type and attribute.
+	AttributeMappings *[]AttributeMapping `json:"attributeMappings,omitempty"`
+
+	// Set to `true` to create bins with an asset type specific for the content category (paper, PET, residual or organic) instead of the general bin asset type. Existing assets keep their asset type.
+	ContentCategoryAssetTypes *bool `json:"contentCategoryAssetTypes,omitempty"`
+
+	// Set to `true` to poll the status of bins above the urgent fill level and of devices with alarms with the minimum interval and to back off for idle devices up to the maximum interval. Other devices are polled with the interval `intervalSec`.
+	AdaptivePolling *bool `json:"adaptivePolling,omitempty"`
+
+	// Minimum interval in seconds for polling devices in adaptive polling mode
+	MinIntervalSec int32 `json:"minIntervalSec,omitempty"`
+
+	// Maximum interval in seconds for polling idle devices in adaptive polling mode
+	MaxIntervalSec int32 `json:"maxIntervalSec,omitempty"`
+
+	// Fill level in percent from which bins are polled with the minimum interval in adaptive polling mode
+	UrgentFillLevel int32 `json:"urgentFillLevel,omitempty"`
 }
 
 // AssertConfigurationRequired checks if the required fields are not zero-ed
 func AssertConfigurationRequired(obj Configuration) error {
+	if obj.AttributeMappings != nil {
+		for _, el := range *obj.AttributeMappings {
+			if err := AssertAttributeMappingRequired(el); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,51 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// EmptyingRoute - Ordered pick-up list of bins to be emptied by a service crew
+type EmptyingRoute struct {
+
+	// Id of the configuration the bins belong to
+	ConfigId int64 `json:"configId,omitempty"`
+
+	// Heuristic used to order the bins (nearest or station)
+	Order string `json:"order,omitempty"`
+
+	// Fill level in percent from which bins are emptied
+	Threshold float64 `json:"threshold,omitempty"`
+
+	// Distance in kilometers between the located stops along the route
+	Distance float64 `json:"distance"`
+
+	// The stops of the route in pick-up order
+	Stops []EmptyingStop `json:"stops"`
+}
+
+// AssertEmptyingRouteRequired checks if the required fields are not zero-ed
+func AssertEmptyingRouteRequired(obj EmptyingRoute) error {
+	for _, el := range obj.Stops {
+		if err := AssertEmptyingStopRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertRecurseEmptyingRouteRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of EmptyingRoute (e.g. [][]EmptyingRoute), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseEmptyingRouteRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aEmptyingRoute, ok := obj.(EmptyingRoute)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertEmptyingRouteRequired(aEmptyingRoute)
+	})
+}
@@ -0,0 +1,72 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// EmptyingStop - A bin to be emptied on a route
+type EmptyingStop struct {
+
+	// Position of the stop within the route starting with 1
+	Sequence int32 `json:"sequence"`
+
+	// Id of the Hailo smart device
+	DeviceId string `json:"deviceId"`
+
+	// Id of the recycling station containing the bin
+	StationId *string `json:"stationId,omitempty"`
+
+	// Id of the Eliona asset of the bin
+	AssetId *int32 `json:"assetId,omitempty"`
+
+	// Latitude of the bin in WGS 84
+	Latitude *float64 `json:"latitude,omitempty"`
+
+	// Longitude of the bin in WGS 84
+	Longitude *float64 `json:"longitude,omitempty"`
+
+	// Address of the bin
+	Address *string `json:"address,omitempty"`
+
+	// Current fill level in percent
+	Volumepercent *float64 `json:"volumepercent,omitempty"`
+
+	// Expected fill level in percent at the next scheduled round
+	ExpPercent *float64 `json:"expPercent,omitempty"`
+
+	// Why the bin is on the route (full or predicted)
+	Reason string `json:"reason"`
+}
+
+// AssertEmptyingStopRequired checks if the required fields are not zero-ed
+func AssertEmptyingStopRequired(obj EmptyingStop) error {
+	elements := map[string]interface{}{
+		"sequence": obj.Sequence,
+		"deviceId": obj.DeviceId,
+		"reason":   obj.Reason,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertRecurseEmptyingStopRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of EmptyingStop (e.g. [][]EmptyingStop), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseEmptyingStopRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aEmptyingStop, ok := obj.(EmptyingStop)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertEmptyingStopRequired(aEmptyingStop)
+	})
+}
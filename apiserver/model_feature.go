@@ -0,0 +1,42 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// Feature - GeoJSON feature with a point geometry and the properties of a Hailo smart device
+type Feature struct {
+
+	// Type of the GeoJSON object
+	Type string `json:"type,omitempty"`
+
+	Geometry Geometry `json:"geometry,omitempty"`
+
+	// Properties of the device, e.g. the asset id, the current fill level and the alarm state
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// AssertFeatureRequired checks if the required fields are not zero-ed
+func AssertFeatureRequired(obj Feature) error {
+	if err := AssertGeometryRequired(obj.Geometry); err != nil {
+		return err
+	}
+	return nil
+}
+
+// AssertRecurseFeatureRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of Feature (e.g. [][]Feature), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseFeatureRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aFeature, ok := obj.(Feature)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertFeatureRequired(aFeature)
+	})
+}
@@ -0,0 +1,42 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// FeatureCollection - GeoJSON feature collection (see RFC 7946)
+type FeatureCollection struct {
+
+	// Type of the GeoJSON object
+	Type string `json:"type,omitempty"`
+
+	// The features of the collection
+	Features []Feature `json:"features,omitempty"`
+}
+
+// AssertFeatureCollectionRequired checks if the required fields are not zero-ed
+func AssertFeatureCollectionRequired(obj FeatureCollection) error {
+	for _, el := range obj.Features {
+		if err := AssertFeatureRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertRecurseFeatureCollectionRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of FeatureCollection (e.g. [][]FeatureCollection), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseFeatureCollectionRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aFeatureCollection, ok := obj.(FeatureCollection)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertFeatureCollectionRequired(aFeatureCollection)
+	})
+}
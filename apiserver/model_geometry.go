@@ -0,0 +1,37 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// Geometry - GeoJSON point geometry
+type Geometry struct {
+
+	// Type of the GeoJSON geometry
+	Type string `json:"type,omitempty"`
+
+	// Longitude and latitude of the point
+	Coordinates []float64 `json:"coordinates,omitempty"`
+}
+
+// AssertGeometryRequired checks if the required fields are not zero-ed
+func AssertGeometryRequired(obj Geometry) error {
+	return nil
+}
+
+// AssertRecurseGeometryRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of Geometry (e.g. [][]Geometry), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseGeometryRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aGeometry, ok := obj.(Geometry)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertGeometryRequired(aGeometry)
+	})
+}
@@ -0,0 +1,40 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// Location - The `Location` of a Hailo smart device overrides the location read from Hailo FDS. If latitude and longitude are null, the location read from Hailo FDS is used.
+type Location struct {
+
+	// Latitude of the device in WGS 84
+	Latitude *float64 `json:"latitude,omitempty"`
+
+	// Longitude of the device in WGS 84
+	Longitude *float64 `json:"longitude,omitempty"`
+
+	// Address of the device
+	Address *string `json:"address,omitempty"`
+}
+
+// AssertLocationRequired checks if the required fields are not zero-ed
+func AssertLocationRequired(obj Location) error {
+	return nil
+}
+
+// AssertRecurseLocationRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of Location (e.g. [][]Location), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseLocationRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aLocation, ok := obj.(Location)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertLocationRequired(aLocation)
+	})
+}
@@ -0,0 +1,80 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// RightSizing - Recommendation to change the volume or the emptying frequency of a bin
+type RightSizing struct {
+
+	// Device id of the bin
+	DeviceId string `json:"deviceId"`
+
+	// Device id of the recycling station the bin belongs to
+	StationId *string `json:"stationId,omitempty"`
+
+	// Content category of the bin
+	ContentCategory *string `json:"contentCategory,omitempty"`
+
+	// Volume of the bin in liters
+	BinVolume *int32 `json:"binVolume,omitempty"`
+
+	// Days covered by the readings of the bin
+	Days float64 `json:"days"`
+
+	// Average increase of the fill level in percent per day
+	FillRate *float64 `json:"fillRate,omitempty"`
+
+	// Average waste in liters per day
+	LitersPerDay *float64 `json:"litersPerDay,omitempty"`
+
+	// Observed emptyings per month
+	EmptyingsPerMonth float64 `json:"emptyingsPerMonth"`
+
+	// Average fill level in percent at emptying
+	AvgFillAtEmptying *float64 `json:"avgFillAtEmptying,omitempty"`
+
+	// Recommended change
+	Recommendation string `json:"recommendation"`
+
+	// Recommended volume of the bin in liters
+	RecommendedVolume *int32 `json:"recommendedVolume,omitempty"`
+
+	// Recommended emptyings per month
+	RecommendedEmptyingsPerMonth *float64 `json:"recommendedEmptyingsPerMonth,omitempty"`
+
+	// Estimated trips saved per month, negative if more trips are needed
+	TripsSavedPerMonth float64 `json:"tripsSavedPerMonth"`
+}
+
+// AssertRightSizingRequired checks if the required fields are not zero-ed
+func AssertRightSizingRequired(obj RightSizing) error {
+	elements := map[string]interface{}{
+		"deviceId":       obj.DeviceId,
+		"recommendation": obj.Recommendation,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertRecurseRightSizingRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of RightSizing (e.g. [][]RightSizing), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseRightSizingRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aRightSizing, ok := obj.(RightSizing)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertRightSizingRequired(aRightSizing)
+	})
+}
@@ -0,0 +1,58 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"time"
+)
+
+// RightSizingReport - Recommendations to change the volume or the emptying frequency of the bins of a configuration based on the fill rates over a date range
+type RightSizingReport struct {
+
+	// Id of the configuration the bins belong to
+	ConfigId int64 `json:"configId,omitempty"`
+
+	// Start of the date range
+	From time.Time `json:"from,omitempty"`
+
+	// End of the date range
+	To time.Time `json:"to,omitempty"`
+
+	// Fill level in percent a bin should have when it is emptied
+	TargetFill float64 `json:"targetFill,omitempty"`
+
+	// Estimated trips saved per month by all recommendations
+	TripsSavedPerMonth float64 `json:"tripsSavedPerMonth"`
+
+	// Recommendations for each bin
+	Bins []RightSizing `json:"bins"`
+}
+
+// AssertRightSizingReportRequired checks if the required fields are not zero-ed
+func AssertRightSizingReportRequired(obj RightSizingReport) error {
+	for _, el := range obj.Bins {
+		if err := AssertRightSizingRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertRecurseRightSizingReportRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of RightSizingReport (e.g. [][]RightSizingReport), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseRightSizingReportRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aRightSizingReport, ok := obj.(RightSizingReport)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertRightSizingReportRequired(aRightSizingReport)
+	})
+}
@@ -0,0 +1,77 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"time"
+)
+
+// SensorAnomaly - Anomaly in the readings of a bin indicating a faulty sensor
+type SensorAnomaly struct {
+
+	// Device id of the bin
+	DeviceId string `json:"deviceId"`
+
+	// Device id of the recycling station the bin belongs to
+	StationId *string `json:"stationId,omitempty"`
+
+	// Rule which detected the anomaly
+	Rule string `json:"rule"`
+
+	// Timestamp of the reading the anomaly starts with
+	Timestamp time.Time `json:"timestamp"`
+
+	// Timestamp of the reading the anomaly ends with
+	Until time.Time `json:"until"`
+
+	// Fill level in percent at the start of the anomaly
+	PreviousVolumepercent *float64 `json:"previousVolumepercent,omitempty"`
+
+	// Fill level in percent at the end of the anomaly
+	Volumepercent *float64 `json:"volumepercent,omitempty"`
+
+	// Openings since the last emptying at the start of the anomaly
+	PreviousOpenings *int32 `json:"previousOpenings,omitempty"`
+
+	// Openings since the last emptying at the end of the anomaly
+	Openings *int32 `json:"openings,omitempty"`
+
+	// Description of the anomaly
+	Description string `json:"description,omitempty"`
+}
+
+// AssertSensorAnomalyRequired checks if the required fields are not zero-ed
+func AssertSensorAnomalyRequired(obj SensorAnomaly) error {
+	elements := map[string]interface{}{
+		"deviceId":  obj.DeviceId,
+		"rule":      obj.Rule,
+		"timestamp": obj.Timestamp,
+		"until":     obj.Until,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertRecurseSensorAnomalyRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of SensorAnomaly (e.g. [][]SensorAnomaly), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseSensorAnomalyRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aSensorAnomaly, ok := obj.(SensorAnomaly)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertSensorAnomalyRequired(aSensorAnomaly)
+	})
+}
@@ -0,0 +1,52 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"time"
+)
+
+// SensorAnomalyReport - Sensor anomalies of the bins of a configuration over a date range
+type SensorAnomalyReport struct {
+
+	// Id of the configuration the bins belong to
+	ConfigId int64 `json:"configId,omitempty"`
+
+	// Start of the date range
+	From time.Time `json:"from,omitempty"`
+
+	// End of the date range
+	To time.Time `json:"to,omitempty"`
+
+	// Anomalies ordered by device and timestamp
+	Anomalies []SensorAnomaly `json:"anomalies"`
+}
+
+// AssertSensorAnomalyReportRequired checks if the required fields are not zero-ed
+func AssertSensorAnomalyReportRequired(obj SensorAnomalyReport) error {
+	for _, el := range obj.Anomalies {
+		if err := AssertSensorAnomalyRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertRecurseSensorAnomalyReportRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of SensorAnomalyReport (e.g. [][]SensorAnomalyReport), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseSensorAnomalyReportRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aSensorAnomalyReport, ok := obj.(SensorAnomalyReport)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertSensorAnomalyReportRequired(aSensorAnomalyReport)
+	})
+}
@@ -0,0 +1,61 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// ServiceLevel - Service level of a bin or aggregated for the bins of a station or content category
+type ServiceLevel struct {
+
+	// Device id of the bin or station or the content category
+	Id string `json:"id"`
+
+	// Number of bins aggregated
+	Bins int32 `json:"bins"`
+
+	// Hours at or above each threshold in the order of the thresholds of the report
+	HoursAbove []float64 `json:"hoursAbove"`
+
+	// Number of times the fill level reached 100 %
+	OverflowEpisodes int32 `json:"overflowEpisodes"`
+
+	// Number of emptyings
+	Emptyings int32 `json:"emptyings"`
+
+	// Average fill level in percent at emptying
+	AvgFillAtEmptying *float64 `json:"avgFillAtEmptying,omitempty"`
+
+	// Number of emptyings with a fill level below 50 %
+	PrematureEmptyings int32 `json:"prematureEmptyings"`
+}
+
+// AssertServiceLevelRequired checks if the required fields are not zero-ed
+func AssertServiceLevelRequired(obj ServiceLevel) error {
+	elements := map[string]interface{}{
+		"id": obj.Id,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertRecurseServiceLevelRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of ServiceLevel (e.g. [][]ServiceLevel), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseServiceLevelRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aServiceLevel, ok := obj.(ServiceLevel)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertServiceLevelRequired(aServiceLevel)
+	})
+}
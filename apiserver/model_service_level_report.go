@@ -0,0 +1,71 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"time"
+)
+
+// ServiceLevelReport - Service levels of the bins of a configuration over a date range, aggregated per bin, station and content category
+type ServiceLevelReport struct {
+
+	// Id of the configuration the bins belong to
+	ConfigId int64 `json:"configId,omitempty"`
+
+	// Start of the date range
+	From time.Time `json:"from,omitempty"`
+
+	// End of the date range
+	To time.Time `json:"to,omitempty"`
+
+	// Fill levels in percent for which the time at or above is reported
+	Thresholds []float64 `json:"thresholds,omitempty"`
+
+	// Service levels of each bin
+	Bins []ServiceLevel `json:"bins"`
+
+	// Service levels of the bins of each recycling station
+	Stations []ServiceLevel `json:"stations"`
+
+	// Service levels of the bins of each content category
+	Categories []ServiceLevel `json:"categories"`
+}
+
+// AssertServiceLevelReportRequired checks if the required fields are not zero-ed
+func AssertServiceLevelReportRequired(obj ServiceLevelReport) error {
+	for _, el := range obj.Bins {
+		if err := AssertServiceLevelRequired(el); err != nil {
+			return err
+		}
+	}
+	for _, el := range obj.Stations {
+		if err := AssertServiceLevelRequired(el); err != nil {
+			return err
+		}
+	}
+	for _, el := range obj.Categories {
+		if err := AssertServiceLevelRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertRecurseServiceLevelReportRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of ServiceLevelReport (e.g. [][]ServiceLevelReport), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseServiceLevelReportRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aServiceLevelReport, ok := obj.(ServiceLevelReport)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertServiceLevelReportRequired(aServiceLevelReport)
+	})
+}
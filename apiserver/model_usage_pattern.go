@@ -0,0 +1,67 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// UsagePattern - Openings of a bin or station per weekday and hour of the day
+type UsagePattern struct {
+
+	// Device id of the bin or station
+	DeviceId string `json:"deviceId"`
+
+	// Kind of the device
+	Kind string `json:"kind,omitempty"`
+
+	// Device id of the recycling station the bin belongs to
+	StationId *string `json:"stationId,omitempty"`
+
+	// Total openings within the date range
+	Openings int32 `json:"openings"`
+
+	// Openings for each weekday from monday to sunday and each hour of the day from 0 to 23
+	Heatmap [][]int32 `json:"heatmap"`
+
+	// Openings for each hour of the day from 0 to 23
+	OpeningsByHour []int32 `json:"openingsByHour"`
+
+	// Openings for each weekday from monday to sunday
+	OpeningsByWeekday []int32 `json:"openingsByWeekday"`
+
+	// Hour of the day with the most openings
+	PeakHour *int32 `json:"peakHour,omitempty"`
+
+	// Weekday with the most openings
+	PeakWeekday *string `json:"peakWeekday,omitempty"`
+}
+
+// AssertUsagePatternRequired checks if the required fields are not zero-ed
+func AssertUsagePatternRequired(obj UsagePattern) error {
+	elements := map[string]interface{}{
+		"deviceId": obj.DeviceId,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertRecurseUsagePatternRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of UsagePattern (e.g. [][]UsagePattern), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseUsagePatternRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aUsagePattern, ok := obj.(UsagePattern)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertUsagePatternRequired(aUsagePattern)
+	})
+}
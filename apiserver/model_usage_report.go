@@ -0,0 +1,55 @@
+/*
+ * Hailo app API
+ *
+ * API to access and configure the Hailo app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"time"
+)
+
+// UsageReport - Usage patterns of the bins and stations of a configuration over a date range
+type UsageReport struct {
+
+	// Id of the configuration the devices belong to
+	ConfigId int64 `json:"configId,omitempty"`
+
+	// Start of the date range
+	From time.Time `json:"from,omitempty"`
+
+	// End of the date range
+	To time.Time `json:"to,omitempty"`
+
+	// Time zone of the weekdays and hours
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Usage patterns of each device
+	Devices []UsagePattern `json:"devices"`
+}
+
+// AssertUsageReportRequired checks if the required fields are not zero-ed
+func AssertUsageReportRequired(obj UsageReport) error {
+	for _, el := range obj.Devices {
+		if err := AssertUsagePatternRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertRecurseUsageReportRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of UsageReport (e.g. [][]UsageReport), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseUsageReportRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aUsageReport, ok := obj.(UsageReport)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertUsageReportRequired(aUsageReport)
+	})
+}
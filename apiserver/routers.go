@@ -12,6 +12,7 @@ package apiserver
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
@@ -72,6 +73,22 @@ func EncodeJSONResponse(i interface{}, status *int, w http.ResponseWriter) error
 	return json.NewEncoder(w).Encode(i)
 }
 
+// EncodeFileResponse writes the file to the http response with an optional status code
+func EncodeFileResponse(file FileResponse, status *int, w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", file.ContentType)
+	if file.Name != "" {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.Name))
+	}
+	if status != nil {
+		w.WriteHeader(*status)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	_, err := w.Write(file.Content)
+	return err
+}
+
 // ReadFormFileToTempFile reads file data from a request form and writes it to a temporary file
 func ReadFormFileToTempFile(r *http.Request, key string) (*os.File, error) {
 	_, fileHeader, err := r.FormFile(key)
@@ -159,6 +176,19 @@ func parseInt32Parameter(param string, required bool) (int32, error) {
 	return int32(val), nil
 }
 
+// parseFloat64Parameter parses a string parameter to a float64.
+func parseFloat64Parameter(param string, required bool) (float64, error) {
+	if param == "" {
+		if required {
+			return 0, errors.New(errMsgRequiredMissing)
+		}
+
+		return 0, nil
+	}
+
+	return strconv.ParseFloat(param, 64)
+}
+
 // parseBoolParameter parses a string parameter to a bool
 func parseBoolParameter(param string) (bool, error) {
 	val, err := strconv.ParseBool(param)
@@ -216,3 +246,27 @@ func parseInt32ArrayParameter(param, delim string, required bool) ([]int32, erro
 
 	return ints, nil
 }
+
+// parseFloat64ArrayParameter parses a string parameter containing array of values to []float64.
+func parseFloat64ArrayParameter(param, delim string, required bool) ([]float64, error) {
+	if param == "" {
+		if required {
+			return nil, errors.New(errMsgRequiredMissing)
+		}
+
+		return nil, nil
+	}
+
+	str := strings.Split(param, delim)
+	floats := make([]float64, len(str))
+
+	for i, s := range str {
+		if v, err := strconv.ParseFloat(s, 64); err != nil {
+			return nil, err
+		} else {
+			floats[i] = v
+		}
+	}
+
+	return floats, nil
+}
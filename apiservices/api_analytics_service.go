@@ -0,0 +1,336 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"context"
+	"fmt"
+	"hailo/analytics"
+	"hailo/apiserver"
+	"hailo/conf"
+	"hailo/eliona"
+	"hailo/hailo"
+	"net/http"
+	"time"
+
+	// embedded time zone database for usage heatmaps, if the container provides no zoneinfo
+	_ "time/tzdata"
+)
+
+// Formats of analysis results
+const (
+	formatJson = "json"
+	formatCsv  = "csv"
+	formatGpx  = "gpx"
+)
+
+// defaultReportDays is the number of days reported up to now, if no date range is requested
+const defaultReportDays = 30
+
+// AnalyticsApiService is a service that implements the logic for the AnalyticsApiServicer
+// This service should implement the business logic for every endpoint for the AnalyticsApi API.
+// Include any external packages or services that will be required by this service.
+type AnalyticsApiService struct {
+}
+
+// NewAnalyticsApiService creates a default api service
+func NewAnalyticsApiService() apiserver.AnalyticsApiServicer {
+	return &AnalyticsApiService{}
+}
+
+// GetGeoJson - Get bins as GeoJSON
+func (s *AnalyticsApiService) GetGeoJson(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
+	config, err := conf.GetConfig(ctx, configId)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	if config == nil {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	geoJson, err := eliona.GeoJson(*config)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, geoJson), nil
+}
+
+// GetEmptyingRoute - Get the emptying route
+func (s *AnalyticsApiService) GetEmptyingRoute(ctx context.Context, configId int64, threshold float64, order string, latitude *float64, longitude *float64, format string) (apiserver.ImplResponse, error) {
+	if order != "" && order != eliona.RouteOrderNearest && order != eliona.RouteOrderStation {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("unknown order %s", order)
+	}
+	if format != "" && format != formatJson && format != formatCsv && format != formatGpx {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("unknown format %s", format)
+	}
+	if err := conf.CheckLocation(apiserver.Location{Latitude: latitude, Longitude: longitude}); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	config, err := conf.GetConfig(ctx, configId)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	if config == nil {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	var start *hailo.Position
+	if latitude != nil && longitude != nil {
+		start = &hailo.Position{Latitude: latitude, Longitude: longitude}
+	}
+	route, err := eliona.EmptyingRoute(*config, threshold, order, start)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	switch format {
+	case formatCsv:
+		content, err := eliona.EmptyingRouteCsv(route)
+		if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		}
+		return apiserver.Response(http.StatusOK, apiserver.FileResponse{Name: "route.csv", ContentType: "text/csv", Content: content}), nil
+	case formatGpx:
+		content, err := eliona.EmptyingRouteGpx(route)
+		if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		}
+		return apiserver.Response(http.StatusOK, apiserver.FileResponse{Name: "route.gpx", ContentType: "application/gpx+xml", Content: content}), nil
+	}
+	return apiserver.Response(http.StatusOK, route), nil
+}
+
+// GetServiceLevels - Get the service level report
+func (s *AnalyticsApiService) GetServiceLevels(ctx context.Context, configId int64, from string, to string, thresholds []float64, format string) (apiserver.ImplResponse, error) {
+	if format != "" && format != formatJson && format != formatCsv {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("unknown format %s", format)
+	}
+	fromTime, toTime, err := parseDateRange(from, to)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	config, err := conf.GetConfig(ctx, configId)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	if config == nil {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	readings, err := conf.GetReadings(ctx, configId, fromTime, toTime)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	previous, err := conf.GetLastReadings(ctx, configId, fromTime)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	report := analytics.ServiceLevels(configId, previous, readings, fromTime, toTime, thresholds)
+	if format == formatCsv {
+		content, err := analytics.ServiceLevelsCsv(report)
+		if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		}
+		return apiserver.Response(http.StatusOK, apiserver.FileResponse{Name: "service-levels.csv", ContentType: "text/csv", Content: content}), nil
+	}
+	return apiserver.Response(http.StatusOK, report), nil
+}
+
+// GetRightSizing - Get right-sizing recommendations
+func (s *AnalyticsApiService) GetRightSizing(ctx context.Context, configId int64, from string, to string, targetFill float64, format string) (apiserver.ImplResponse, error) {
+	if format != "" && format != formatJson && format != formatCsv {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("unknown format %s", format)
+	}
+	if targetFill < 0 || targetFill > 100 {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("invalid target fill level %v", targetFill)
+	}
+	fromTime, toTime, err := parseDateRange(from, to)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	config, err := conf.GetConfig(ctx, configId)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	if config == nil {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	readings, err := conf.GetReadings(ctx, configId, fromTime, toTime)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	report := analytics.RightSizing(configId, readings, fromTime, toTime, targetFill)
+	if format == formatCsv {
+		content, err := analytics.RightSizingCsv(report)
+		if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		}
+		return apiserver.Response(http.StatusOK, apiserver.FileResponse{Name: "right-sizing.csv", ContentType: "text/csv", Content: content}), nil
+	}
+	return apiserver.Response(http.StatusOK, report), nil
+}
+
+// GetBatteryReplacements - Get the battery replacement plan
+func (s *AnalyticsApiService) GetBatteryReplacements(ctx context.Context, configId int64, days int32, format string) (apiserver.ImplResponse, error) {
+	if format != "" && format != formatJson && format != formatCsv {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("unknown format %s", format)
+	}
+	if days < 0 {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("invalid number of days %d", days)
+	}
+	config, err := conf.GetConfig(ctx, configId)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	if config == nil {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	plan, err := eliona.BatteryReplacements(*config, days)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	if format == formatCsv {
+		content, err := eliona.BatteryReplacementsCsv(plan)
+		if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		}
+		return apiserver.Response(http.StatusOK, apiserver.FileResponse{Name: "battery-replacements.csv", ContentType: "text/csv", Content: content}), nil
+	}
+	return apiserver.Response(http.StatusOK, plan), nil
+}
+
+// GetSensorAnomalies - Get sensor anomalies
+func (s *AnalyticsApiService) GetSensorAnomalies(ctx context.Context, configId int64, from string, to string, rule string, format string) (apiserver.ImplResponse, error) {
+	if format != "" && format != formatJson && format != formatCsv {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("unknown format %s", format)
+	}
+	if rule != "" && rule != analytics.AnomalyStuckReading && rule != analytics.AnomalyImplausibleJump &&
+		rule != analytics.AnomalyFillDecrease && rule != analytics.AnomalyOpeningsBackwards {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("unknown rule %s", rule)
+	}
+	fromTime, toTime, err := parseDateRange(from, to)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	config, err := conf.GetConfig(ctx, configId)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	if config == nil {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	readings, err := conf.GetReadings(ctx, configId, fromTime, toTime)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	report := apiserver.SensorAnomalyReport{
+		ConfigId:  configId,
+		From:      fromTime,
+		To:        toTime,
+		Anomalies: []apiserver.SensorAnomaly{},
+	}
+	for _, anomaly := range analytics.SensorAnomalies(readings) {
+		if rule == "" || anomaly.Rule == rule {
+			report.Anomalies = append(report.Anomalies, anomaly)
+		}
+	}
+	if format == formatCsv {
+		content, err := analytics.SensorAnomaliesCsv(report)
+		if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		}
+		return apiserver.Response(http.StatusOK, apiserver.FileResponse{Name: "anomalies.csv", ContentType: "text/csv", Content: content}), nil
+	}
+	return apiserver.Response(http.StatusOK, report), nil
+}
+
+// GetUsage - Get usage heatmaps
+func (s *AnalyticsApiService) GetUsage(ctx context.Context, configId int64, from string, to string, kind string, timeZone string, format string) (apiserver.ImplResponse, error) {
+	if format != "" && format != formatJson && format != formatCsv {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("unknown format %s", format)
+	}
+	if kind != "" && kind != "bin" && kind != "station" {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("unknown kind %s", kind)
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("unknown time zone %s: %w", timeZone, err)
+	}
+	fromTime, toTime, err := parseDateRange(from, to)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	config, err := conf.GetConfig(ctx, configId)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	if config == nil {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	usages, err := conf.GetUsages(ctx, configId, fromTime, toTime)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	var kindUsages []conf.Usage
+	for _, usage := range usages {
+		if kind == "" || usage.Kind == kind {
+			kindUsages = append(kindUsages, usage)
+		}
+	}
+	report := analytics.UsagePatterns(configId, kindUsages, fromTime, toTime, location)
+	if format == formatCsv {
+		content, err := analytics.UsagePatternsCsv(report)
+		if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		}
+		return apiserver.Response(http.StatusOK, apiserver.FileResponse{Name: "usage.csv", ContentType: "text/csv", Content: content}), nil
+	}
+	return apiserver.Response(http.StatusOK, report), nil
+}
+
+// parseDateRange parses the start and end of a date range given as date (e.g. 2023-01-31) or timestamp in RFC 3339.
+// The end is exclusive, so an end given as date includes the whole day. The range ends now by default and starts the
+// default number of days before the end.
+func parseDateRange(from string, to string) (time.Time, time.Time, error) {
+	toTime := time.Now()
+	if to != "" {
+		parsed, dateOnly, err := parseDate(to)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end of range %s: %w", to, err)
+		}
+		toTime = parsed
+		if dateOnly {
+			toTime = parsed.AddDate(0, 0, 1)
+		}
+	}
+	fromTime := toTime.AddDate(0, 0, -defaultReportDays)
+	if from != "" {
+		parsed, _, err := parseDate(from)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid start of range %s: %w", from, err)
+		}
+		fromTime = parsed
+	}
+	if !fromTime.Before(toTime) {
+		return time.Time{}, time.Time{}, fmt.Errorf("start of range %s is not before end %s", fromTime, toTime)
+	}
+	return fromTime, toTime, nil
+}
+
+// parseDate parses a date or a timestamp in RFC 3339 and returns true, if only a date was given
+func parseDate(date string) (time.Time, bool, error) {
+	if parsed, err := time.Parse("2006-01-02", date); err == nil {
+		return parsed, true, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, date)
+	return parsed, false, err
+}
@@ -0,0 +1,50 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseDateRange(t *testing.T) {
+	// the end given as date includes the whole day
+	from, to, err := parseDateRange("2023-01-01", "2023-01-31")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), to)
+
+	// a single day
+	from, to, err = parseDateRange("2023-01-31", "2023-01-31")
+	assert.Nil(t, err)
+	assert.Equal(t, 24*time.Hour, to.Sub(from))
+
+	// the end given as timestamp is used as is
+	_, to, err = parseDateRange("2023-01-01", "2023-01-31T12:00:00Z")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, 1, 31, 12, 0, 0, 0, time.UTC), to)
+
+	// the start defaults to 30 days before the end
+	from, _, err = parseDateRange("", "2023-01-31")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), from)
+
+	_, _, err = parseDateRange("2023-02-01", "2023-01-31")
+	assert.NotNil(t, err)
+	_, _, err = parseDateRange("", "31.01.2023")
+	assert.NotNil(t, err)
+}
@@ -41,3 +41,18 @@ func (s *AssetMappingApiService) GetAssetMappings(ctx context.Context, configId
 	}
 	return apiserver.Response(http.StatusOK, assetMappings), nil
 }
+
+// PutAssetMappingLocation - Overrides the location of a device
+func (s *AssetMappingApiService) PutAssetMappingLocation(ctx context.Context, configId int64, deviceId string, location apiserver.Location) (apiserver.ImplResponse, error) {
+	if err := conf.CheckLocation(location); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	assetMappings, err := conf.SetDeviceLocation(ctx, configId, deviceId, location)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	if len(assetMappings) == 0 {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	return apiserver.Response(http.StatusOK, assetMappings), nil
+}
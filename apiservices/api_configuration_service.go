@@ -19,6 +19,7 @@ import (
 	"context"
 	"hailo/apiserver"
 	"hailo/conf"
+	"hailo/eliona"
 	"net/http"
 )
 
@@ -42,6 +43,7 @@ func (s *ConfigurationApiService) DeleteConfigurationById(ctx context.Context, c
 	if count == 0 {
 		return apiserver.ImplResponse{Code: http.StatusNotFound}, err
 	}
+	eliona.ForgetAttributeMappings(configId)
 	return apiserver.ImplResponse{Code: http.StatusNoContent}, err
 }
 
@@ -81,5 +83,6 @@ func (s *ConfigurationApiService) PutConfigurationById(ctx context.Context, conf
 	if err != nil {
 		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
 	}
+	eliona.ForgetAttributeMappings(configId)
 	return apiserver.Response(http.StatusCreated, upsertedConfig), nil
 }
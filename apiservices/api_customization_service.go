@@ -28,14 +28,18 @@ func NewCustomizationApiService() apiserver.CustomizationApiServicer {
 }
 
 // GetDashboardTemplateByName - Get a full dashboard template
-func (s *CustomizationApiService) GetDashboardTemplateByName(ctx context.Context, dashboardTemplateName string, projectId string) (apiserver.ImplResponse, error) {
-	if dashboardTemplateName == "Hailo Smart Waste" {
-		dashboard, err := eliona.HailoSmartWasteDashboard(projectId)
-		if err != nil {
-			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
-		}
-		return apiserver.Response(http.StatusOK, dashboard), nil
-	} else {
+func (s *CustomizationApiService) GetDashboardTemplateByName(ctx context.Context, dashboardTemplateName string, projectId string, stationAssetId int32, hubAssetId int32, contentCategory string, configId int64) (apiserver.ImplResponse, error) {
+	dashboard, found, err := eliona.DashboardFromTemplate(ctx, dashboardTemplateName, projectId, eliona.DashboardScope{
+		StationAssetId:  stationAssetId,
+		HubAssetId:      hubAssetId,
+		ContentCategory: contentCategory,
+		ConfigId:        configId,
+	})
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	if !found {
 		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
 	}
+	return apiserver.Response(http.StatusOK, dashboard), nil
 }
@@ -17,6 +17,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/eliona-smart-building-assistant/go-eliona/app"
 	"github.com/eliona-smart-building-assistant/go-eliona/asset"
 	"github.com/eliona-smart-building-assistant/go-eliona/dashboard"
@@ -30,6 +32,7 @@ import (
 	"hailo/eliona"
 	"hailo/hailo"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -59,6 +62,33 @@ func initialization() {
 		dashboard.InitWidgetTypeFile("eliona/widget-type-hailo.json"),
 		dashboard.InitWidgetTypeFile("eliona/widget-type-hailo-station.json"),
 	)
+
+	// Patch the app to v2.1.0
+	app.Patch(conn, app.AppName(), "020100",
+		app.ExecSqlFile("conf/v2.1.0.sql"),
+		asset.InitAssetTypeFile("eliona/asset-type-bin.json"),
+		asset.InitAssetTypeFile("eliona/asset-type-recycling-station.json"),
+		asset.InitAssetTypeFile("eliona/asset-type-digital-hub.json"),
+		eliona.InitContentCategoryAssetTypes,
+		dashboard.InitWidgetTypeFile("eliona/widget-type-hailo-health.json"),
+	)
+
+	// Load the attribute mappings of the app and the mapping file and check that all attributes mapped are defined in
+	// the asset types
+	err := eliona.LoadAttributeMappings()
+	if err != nil {
+		log.Fatal("Hailo", "Could not load attribute mappings: %v", err)
+	}
+	eliona.CheckAttributeMappings()
+
+	// Load the mapping of devices to assets, so no queries are necessary during collecting data
+	err = conf.LoadAssetIds(ctx)
+	if err != nil {
+		log.Error("Hailo", "Could not load asset mappings: %v", err)
+	}
+
+	// Keep new data in order behind data queued before the start of the app
+	eliona.InitOutboxPending()
 }
 
 // collectData collects data based on the configured FDS endpoints in table hailo.config. For each FDS endpoint the
@@ -92,12 +122,18 @@ func collectData() {
 				"FDS Fds Endpoint: %v\n"+
 				"FDS Fds Auth Server: %v\n"+
 				"Auth Timeout: %d\n"+
-				"Request Timeout: %d",
+				"Request Timeout: %d\n"+
+				"Concurrency: %d\n"+
+				"Requests per second: %d\n"+
+				"Adaptive polling: %t",
 				config.Id,
 				*config.FdsServer,
 				config.AuthServer,
 				config.AuthTimeout,
-				config.RequestTimeout)
+				config.RequestTimeout,
+				config.Concurrency,
+				config.RequestsPerSec,
+				common.Val(config.AdaptivePolling))
 		}
 
 		// Runs the ReadNode. If the current node is currently running, skip the execution
@@ -110,10 +146,18 @@ func collectData() {
 			// Collect data for the config
 			collectDataForConfig(c)
 
+			// Report the state of the circuit breaker, e.g. if the FDS endpoint is not reachable
+			if state := hailo.CircuitState(c); state != common.Val(c.CircuitState) {
+				_, err := conf.SetConfigCircuitState(context.Background(), c, state)
+				if err != nil {
+					log.Error("Hailo", "Could not set circuit state for config %d: %v", common.Val(c.Id), err)
+				}
+			}
+
 			log.Info("Hailo", "Collecting %d finished", c.Id)
 
-			// Waits until the time is excited
-			time.Sleep(time.Second * time.Duration(c.IntervalSec))
+			// Waits until the time is excited, in adaptive polling mode the minimum interval
+			time.Sleep(eliona.PollingInterval(c))
 
 		}, config, config.Id)
 	}
@@ -124,6 +168,7 @@ func collectData() {
 func listenApiRequests() {
 	err := http.ListenAndServe(":"+common.Getenv("API_SERVER_PORT", "3000"), utilshttp.NewCORSEnabledHandler(
 		apiserver.NewRouter(
+			apiserver.NewAnalyticsApiController(apiservices.NewAnalyticsApiService()),
 			apiserver.NewAssetMappingApiController(apiservices.NewAssetMappingApiService()),
 			apiserver.NewConfigurationApiController(apiservices.NewConfigurationApiService()),
 			apiserver.NewCustomizationApiController(apiservices.NewCustomizationApiService()),
@@ -133,81 +178,155 @@ func listenApiRequests() {
 }
 
 // collectDataForConfig reads specification of all devices in the given connection. For all devices found asset
-// data is written. In case of stations (group multiple component devices) data for each component is read and
-// written.
+// data is written. The devices are processed in parallel by the configured number of workers.
 func collectDataForConfig(config apiserver.Configuration) {
 
-	// Read specs from Hailo FDS
-	specs, err := hailo.GetSpecs(config)
-	if err != nil {
-		log.Error("Hailo", "Could not read specs for config %d: %v", config.Id, err)
-		return
-	}
-
-	// For each spec write asset data
-	for _, spec := range specs.Data {
+	report := eliona.NewReport(config)
 
-		// If necessary create assets in eliona
-		err = eliona.CreateAssetsIfNecessary(config, spec)
-		if err != nil {
+	// Read specs from Hailo FDS, in adaptive polling mode only if not read within the configured interval
+	specs, polled := eliona.PolledSpecs(config, time.Now())
+	if !polled {
+		var err error
+		specs, err = hailo.GetSpecs(config)
+		if errors.Is(err, hailo.ErrCircuitOpen) {
+			log.Debug("Hailo", "Skip reading specs for config %d, because the FDS endpoint is not reachable", common.Val(config.Id))
 			return
 		}
-
-		// Writing asset data for specification
-		err = eliona.UpsertDataForDevices(config, spec)
 		if err != nil {
+			log.Error("Hailo", "Could not read specs for config %d: %v", config.Id, err)
 			return
 		}
+		eliona.ForgetRemovedDevices(config, specs.Data)
+		eliona.RememberPolledSpecs(config, specs, time.Now())
+	}
 
-		// Get Status
-		status, err := hailo.GetStatus(config, spec.DeviceId)
-		if err != nil {
-			log.Error("Hailo", "Could not read status for config %d and device '%s': %v", config.Id, spec.DeviceId, err)
-			return
+	// Start workers which process the devices
+	workers := config.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	devices := make(chan hailo.Spec)
+	var waitGroup sync.WaitGroup
+	for worker := int32(0); worker < workers; worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for spec := range devices {
+				collectDataForDevice(config, spec, report)
+			}
+		}()
+	}
+
+	// For each spec write asset data
+	for _, spec := range specs.Data {
+		devices <- spec
+	}
+	close(devices)
+	waitGroup.Wait()
+
+	// Report problems with single devices
+	report.Log()
+}
+
+// collectDataForDevice writes asset data for the device with the given specification. The kind of the device defined
+// by the device type decides how the data is written, devices with unknown device type are skipped. In case of
+// stations (group multiple component devices) data for each component is read and written. All problems are added to
+// the report.
+func collectDataForDevice(config apiserver.Configuration, spec hailo.Spec, report *eliona.Report) {
+	kind := eliona.SupportedDeviceKind(spec)
+	if kind == nil {
+		return
+	}
+	report.AddDevice()
+
+	// A problem with a single device must not stop the collection for other devices
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("Hailo", "Panic while collecting data for config %d and device '%s': %v", config.Id, spec.DeviceId, r)
+			report.AddError(spec.DeviceId, fmt.Errorf("panic: %v", r))
 		}
+	}()
 
-		// Decide if device is station or single container
-		if status.IsStation() {
+	// In adaptive polling mode skip devices polled recently enough
+	if !eliona.PollingDue(config, spec.DeviceId, time.Now()) {
+		log.Debug("Hailo", "Skip polling for config %d and device '%s', because the device is not due", common.Val(config.Id), spec.DeviceId)
+		return
+	}
 
-			// Upsert status for station
-			err = eliona.UpsertDataForStation(config, status)
-			if err != nil {
-				return
-			}
+	// If necessary create assets in eliona
+	err := eliona.CreateAssetsIfNecessary(config, spec)
+	if err != nil {
+		report.AddError(spec.DeviceId, err)
+		eliona.SchedulePollingAfterError(config, spec.DeviceId, time.Now())
+		return
+	}
 
-			// Process station components
-			for _, compStatus := range status.DeviceTypeSpecific.CompStatuses {
+	// Writing asset data for specification
+	err = eliona.UpsertDataForDevices(config, spec)
+	if err != nil {
+		report.AddError(spec.DeviceId, err)
+		eliona.SchedulePollingAfterError(config, spec.DeviceId, time.Now())
+		return
+	}
 
-				// Get diag for component
-				diag, err := hailo.GetDiag(config, compStatus.DeviceId)
-				if err != nil {
-					log.Error("Hailo", "Could not read diag for config %d and component '%s': %v", config.Id, compStatus.DeviceId, err)
-					return
-				}
+	// Get Status
+	status, err := hailo.GetStatus(config, spec.DeviceId)
+	if err != nil {
+		log.Error("Hailo", "Could not read status for config %d and device '%s': %v", config.Id, spec.DeviceId, err)
+		report.AddError(spec.DeviceId, err)
+		eliona.SchedulePollingAfterError(config, spec.DeviceId, time.Now())
+		return
+	}
+	eliona.SchedulePolling(config, status, time.Now())
 
-				// Upsert status and diag for station components
-				err = eliona.UpsertDataForBin(config, compStatus, diag)
-				if err != nil {
-					return
-				}
+	// Get diag, for stations including the diags of all components. Without diag only the status of a station is
+	// written.
+	diag, err := hailo.GetDiag(config, status.DeviceId)
+	if err != nil {
+		log.Error("Hailo", "Could not read diag for config %d and device '%s': %v", config.Id, status.DeviceId, err)
+		report.AddError(status.DeviceId, err)
+		if !kind.Station {
+			return
+		}
+	}
+	var deviceDiag *hailo.Diag
+	if err == nil {
+		deviceDiag = &diag
+	}
 
-			}
+	// Upsert status and diag for the device
+	err = kind.UpsertData(config, status, deviceDiag, report)
+	if err != nil {
+		report.AddError(status.DeviceId, err)
+		return
+	}
+	if !kind.Station {
+		return
+	}
 
-		} else {
+	// Process station components
+	for _, compStatus := range status.DeviceTypeSpecific.CompStatuses {
+		compKind := eliona.SupportedDeviceKind(spec.ComponentSpec(compStatus.DeviceId))
+		if compKind == nil {
+			continue
+		}
 
-			// Get diag for single container
-			diag, err := hailo.GetDiag(config, status.DeviceId)
+		// Get diag for component, read separately only if not embedded in the station diag
+		compDiag, ok := diag.ComponentDiag(compStatus.DeviceId)
+		if !ok {
+			compDiag, err = hailo.GetDiag(config, compStatus.DeviceId)
 			if err != nil {
-				log.Error("Hailo", "Could not read diag for config %d and station '%s': %v", config.Id, status.DeviceId, err)
-				return
+				log.Error("Hailo", "Could not read diag for config %d and component '%s': %v", config.Id, compStatus.DeviceId, err)
+				report.AddError(compStatus.DeviceId, err)
+				continue
 			}
+		}
 
-			// Upsert status and diag for station single container
-			err = eliona.UpsertDataForBin(config, status, diag)
-			if err != nil {
-				return
-			}
+		// Upsert status and diag for station components
+		err = compKind.UpsertData(config, compStatus, &compDiag, report)
+		if err != nil {
+			report.AddError(compStatus.DeviceId, err)
+			continue
 		}
 	}
-
 }
@@ -0,0 +1,142 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"context"
+	"hailo/apiserver"
+	"sync"
+)
+
+// assetIdKey identifies a Hailo device within a configuration and an Eliona project
+type assetIdKey struct {
+	configId int64
+	projId   string
+	deviceId string
+}
+
+// locationKey identifies a Hailo device within a configuration
+type locationKey struct {
+	configId int64
+	deviceId string
+}
+
+// assetIdCache holds the mapping of Hailo devices to Eliona assets and the location overrides read from table
+// hailo.asset. If the cache is loaded, a device not found in the cache has no mapping and no query is necessary.
+var assetIdCache = struct {
+	sync.RWMutex
+	loaded    bool
+	ids       map[assetIdKey]int32
+	locations map[locationKey]apiserver.Location
+}{ids: make(map[assetIdKey]int32), locations: make(map[locationKey]apiserver.Location)}
+
+// LoadAssetIds reads all asset mappings and location overrides to the cache
+func LoadAssetIds(ctx context.Context) error {
+	_, err := GetAssetMappings(ctx, 0)
+	if err != nil {
+		return err
+	}
+	assetIdCache.Lock()
+	defer assetIdCache.Unlock()
+	assetIdCache.loaded = true
+	return nil
+}
+
+// refreshAssetIds reads the asset mappings of the configuration with the read function and replaces all cached asset
+// ids and location overrides of the configuration, so mappings deleted in the meantime are removed from the cache.
+// With configuration id 0 the mappings of all configurations are replaced. The cache is locked while reading, so
+// mappings cached concurrently after their insert are not lost.
+func refreshAssetIds(configId int64, read func() ([]apiserver.AssetMapping, error)) ([]apiserver.AssetMapping, error) {
+	assetIdCache.Lock()
+	defer assetIdCache.Unlock()
+	assetMappings, err := read()
+	if err != nil {
+		return nil, err
+	}
+	for key := range assetIdCache.ids {
+		if configId == 0 || key.configId == configId {
+			delete(assetIdCache.ids, key)
+		}
+	}
+	for key := range assetIdCache.locations {
+		if configId == 0 || key.configId == configId {
+			delete(assetIdCache.locations, key)
+		}
+	}
+	for _, assetMapping := range assetMappings {
+		assetIdCache.ids[assetIdKey{int64(assetMapping.ConfigId), assetMapping.ProjId, assetMapping.DeviceId}] = assetMapping.AssetId
+		if location := assetMappingLocation(assetMapping); location != nil {
+			assetIdCache.locations[locationKey{int64(assetMapping.ConfigId), assetMapping.DeviceId}] = *location
+		}
+	}
+	return assetMappings, nil
+}
+
+// cachedAssetId returns the cached asset id for the device. The second value is true, if the cache is able to answer
+// the lookup, either because the device is cached or the cache is loaded and the device has no mapping.
+func cachedAssetId(configId int64, projId string, deviceId string) (*int32, bool) {
+	assetIdCache.RLock()
+	defer assetIdCache.RUnlock()
+	if assetId, ok := assetIdCache.ids[assetIdKey{configId, projId, deviceId}]; ok {
+		return &assetId, true
+	}
+	return nil, assetIdCache.loaded
+}
+
+// cacheAssetId remembers the asset id for the device
+func cacheAssetId(configId int64, projId string, deviceId string, assetId int32) {
+	assetIdCache.Lock()
+	defer assetIdCache.Unlock()
+	assetIdCache.ids[assetIdKey{configId, projId, deviceId}] = assetId
+}
+
+// cachedLocation returns the cached location override for the device. The second value is true, if the cache is
+// able to answer the lookup.
+func cachedLocation(configId int64, deviceId string) (*apiserver.Location, bool) {
+	assetIdCache.RLock()
+	defer assetIdCache.RUnlock()
+	if location, ok := assetIdCache.locations[locationKey{configId, deviceId}]; ok {
+		return &location, true
+	}
+	return nil, assetIdCache.loaded
+}
+
+// cacheLocation remembers the location override for the device. A nil location removes the override.
+func cacheLocation(configId int64, deviceId string, location *apiserver.Location) {
+	assetIdCache.Lock()
+	defer assetIdCache.Unlock()
+	if location == nil {
+		delete(assetIdCache.locations, locationKey{configId, deviceId})
+		return
+	}
+	assetIdCache.locations[locationKey{configId, deviceId}] = *location
+}
+
+// uncacheAssetIds removes all asset ids and location overrides of the configuration from the cache
+func uncacheAssetIds(configId int64) {
+	assetIdCache.Lock()
+	defer assetIdCache.Unlock()
+	for key := range assetIdCache.ids {
+		if key.configId == configId {
+			delete(assetIdCache.ids, key)
+		}
+	}
+	for key := range assetIdCache.locations {
+		if key.configId == configId {
+			delete(assetIdCache.locations, key)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"hailo/apiserver"
+	"testing"
+)
+
+func TestAssetIdCache(t *testing.T) {
+	_, ok := cachedAssetId(1, "99", "bin-1")
+	assert.False(t, ok)
+
+	cacheAssetId(1, "99", "bin-1", 4711)
+	cacheAssetId(2, "99", "bin-1", 4712)
+	assetId, ok := cachedAssetId(1, "99", "bin-1")
+	assert.True(t, ok)
+	assert.Equal(t, int32(4711), *assetId)
+
+	uncacheAssetIds(1)
+	_, ok = cachedAssetId(1, "99", "bin-1")
+	assert.False(t, ok)
+	assetId, ok = cachedAssetId(2, "99", "bin-1")
+	assert.True(t, ok)
+	assert.Equal(t, int32(4712), *assetId)
+
+	assetIdCache.loaded = true
+	assetId, ok = cachedAssetId(1, "99", "bin-1")
+	assert.True(t, ok)
+	assert.Nil(t, assetId)
+
+	// refreshing replaces the mappings of the configuration and removes deleted mappings
+	cacheAssetId(2, "99", "bin-2", 4713)
+	cacheLocation(2, "bin-2", &apiserver.Location{Address: common.Ptr("Main Street 1")})
+	_, err := refreshAssetIds(2, func() ([]apiserver.AssetMapping, error) {
+		return []apiserver.AssetMapping{{ConfigId: 2, ProjId: "99", DeviceId: "bin-1", AssetId: 4714}}, nil
+	})
+	assert.Nil(t, err)
+	assetId, _ = cachedAssetId(2, "99", "bin-1")
+	assert.Equal(t, int32(4714), *assetId)
+	assetId, ok = cachedAssetId(2, "99", "bin-2")
+	assert.True(t, ok)
+	assert.Nil(t, assetId)
+	location, _ := cachedLocation(2, "bin-2")
+	assert.Nil(t, location)
+}
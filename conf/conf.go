@@ -17,6 +17,7 @@ package conf
 
 import (
 	"context"
+	"fmt"
 	"github.com/eliona-smart-building-assistant/go-eliona/app"
 	"github.com/eliona-smart-building-assistant/go-utils/common"
 	"github.com/eliona-smart-building-assistant/go-utils/db"
@@ -29,6 +30,16 @@ import (
 )
 
 const DefaultInactiveTimeout = 60 * 60 * 24 // time until set a container to inactive (sec)
+const DefaultRefreshInterval = 60 * 60      // time until unchanged data is written again (sec)
+const DefaultConcurrency = 4                // devices processed in parallel
+const DefaultRequestsPerSec = 5             // requests per second sent to a FDS host
+const DefaultMaxRetries = 3                 // retries for failed FDS requests
+const DefaultRetryDelay = 1                 // delay before first retry (sec)
+const DefaultBreakerThreshold = 5           // failed FDS requests until the circuit breaker opens
+const DefaultBreakerProbe = 5 * 60          // time until an open circuit breaker probes the FDS endpoint (sec)
+const DefaultMinInterval = 60               // minimum interval for adaptive polling, also the lower bound (sec)
+const DefaultMaxInterval = 60 * 60          // maximum interval for adaptive polling of idle devices (sec)
+const DefaultUrgentFillLevel = 80           // fill level from which bins are polled with the minimum interval (%)
 
 type FdsConfig struct {
 	Name       string `json:"username"`
@@ -50,20 +61,24 @@ func GetConfigs(ctx context.Context) ([]apiserver.Configuration, error) {
 	return apiConfigs, nil
 }
 
+// GetAssetMappings reads the mapping of Hailo devices to Eliona assets and replaces the cached asset ids of the
+// configuration
 func GetAssetMappings(ctx context.Context, configId int64) ([]apiserver.AssetMapping, error) {
 	var mods []qm.QueryMod
 	if configId > 0 {
 		mods = append(mods, dbhailo.AssetWhere.ConfigID.EQ(configId))
 	}
-	dbAssetMappings, err := dbhailo.Assets(mods...).All(ctx, db.Database(app.AppName()))
-	if err != nil {
-		return nil, err
-	}
-	var apiAssetMappings []apiserver.AssetMapping
-	for _, dbAssetMapping := range dbAssetMappings {
-		apiAssetMappings = append(apiAssetMappings, *apiAssetMappingFromDbAssetMapping(dbAssetMapping))
-	}
-	return apiAssetMappings, nil
+	return refreshAssetIds(configId, func() ([]apiserver.AssetMapping, error) {
+		dbAssetMappings, err := dbhailo.Assets(mods...).All(ctx, db.Database(app.AppName()))
+		if err != nil {
+			return nil, err
+		}
+		var apiAssetMappings []apiserver.AssetMapping
+		for _, dbAssetMapping := range dbAssetMappings {
+			apiAssetMappings = append(apiAssetMappings, *apiAssetMappingFromDbAssetMapping(dbAssetMapping))
+		}
+		return apiAssetMappings, nil
+	})
 }
 
 // InsertConfig inserts or updates
@@ -96,9 +111,24 @@ func apiAssetMappingFromDbAssetMapping(dbAssetMapping *dbhailo.Asset) *apiserver
 	apiAssetMapping.DeviceId = dbAssetMapping.DeviceID
 	apiAssetMapping.ConfigId = int32(dbAssetMapping.ConfigID)
 	apiAssetMapping.ProjId = dbAssetMapping.ProjID
+	apiAssetMapping.Latitude = dbAssetMapping.Latitude.Ptr()
+	apiAssetMapping.Longitude = dbAssetMapping.Longitude.Ptr()
+	apiAssetMapping.Address = dbAssetMapping.Address.Ptr()
 	return &apiAssetMapping
 }
 
+// assetMappingLocation returns the location override of the asset mapping or nil, if no location is defined
+func assetMappingLocation(assetMapping apiserver.AssetMapping) *apiserver.Location {
+	if assetMapping.Latitude == nil && assetMapping.Longitude == nil && assetMapping.Address == nil {
+		return nil
+	}
+	return &apiserver.Location{
+		Latitude:  assetMapping.Latitude,
+		Longitude: assetMapping.Longitude,
+		Address:   assetMapping.Address,
+	}
+}
+
 func apiConfigFromDbConfig(dbConfig *dbhailo.Config) *apiserver.Configuration {
 	var apiConfig apiserver.Configuration
 	apiConfig.Id = &dbConfig.AppID
@@ -106,6 +136,14 @@ func apiConfigFromDbConfig(dbConfig *dbhailo.Config) *apiserver.Configuration {
 	apiConfig.Enable = dbConfig.Enable.Ptr()
 	apiConfig.Description = dbConfig.Description.Ptr()
 	apiConfig.InactiveTimeout = getInactiveTimeout(dbConfig)
+	apiConfig.RefreshIntervalSec = getRefreshInterval(dbConfig)
+	apiConfig.Concurrency = getConcurrency(dbConfig)
+	apiConfig.RequestsPerSec = getRequestsPerSec(dbConfig)
+	apiConfig.MaxRetries = common.Ptr(getMaxRetries(dbConfig))
+	apiConfig.RetryDelaySec = getRetryDelay(dbConfig)
+	apiConfig.BreakerThreshold = getBreakerThreshold(dbConfig)
+	apiConfig.BreakerProbeSec = getBreakerProbe(dbConfig)
+	apiConfig.CircuitState = dbConfig.CircuitState.Ptr()
 	var fdsConfig FdsConfig
 	_ = dbConfig.Config.Unmarshal(&fdsConfig)
 	apiConfig.Username = &fdsConfig.Name
@@ -116,6 +154,16 @@ func apiConfigFromDbConfig(dbConfig *dbhailo.Config) *apiserver.Configuration {
 	apiConfig.IntervalSec = dbConfig.IntervalSec
 	apiConfig.RequestTimeout = dbConfig.RequestTimeout
 	apiConfig.ProjIds = common.Ptr[[]string](dbConfig.ProjIds)
+	if dbConfig.AttributeMappings.Valid {
+		var attributeMappings []apiserver.AttributeMapping
+		_ = dbConfig.AttributeMappings.Unmarshal(&attributeMappings)
+		apiConfig.AttributeMappings = &attributeMappings
+	}
+	apiConfig.ContentCategoryAssetTypes = dbConfig.ContentCategoryAssetTypes.Ptr()
+	apiConfig.AdaptivePolling = dbConfig.AdaptivePolling.Ptr()
+	apiConfig.MinIntervalSec = getMinInterval(dbConfig)
+	apiConfig.MaxIntervalSec = getMaxInterval(dbConfig)
+	apiConfig.UrgentFillLevel = getUrgentFillLevel(dbConfig)
 	return &apiConfig
 }
 
@@ -125,13 +173,28 @@ func dbConfigFromApiConfig(apiConfig *apiserver.Configuration) *dbhailo.Config {
 	dbConfig.AssetID = null.Int32FromPtr(apiConfig.AssetId)
 	dbConfig.Enable = null.BoolFromPtr(apiConfig.Enable)
 	dbConfig.Description = null.StringFromPtr(apiConfig.Description)
+	dbConfig.ContentCategoryAssetTypes = null.BoolFromPtr(apiConfig.ContentCategoryAssetTypes)
+	dbConfig.AdaptivePolling = null.BoolFromPtr(apiConfig.AdaptivePolling)
+	dbConfig.MinIntervalSec = null.Int32From(apiConfig.MinIntervalSec)
+	dbConfig.MaxIntervalSec = null.Int32From(apiConfig.MaxIntervalSec)
+	dbConfig.UrgentFillLevel = null.Int32From(apiConfig.UrgentFillLevel)
 	dbConfig.InactiveTimeout = null.Int32From(apiConfig.InactiveTimeout)
+	dbConfig.RefreshIntervalSec = null.Int32From(apiConfig.RefreshIntervalSec)
+	dbConfig.Concurrency = null.Int32From(apiConfig.Concurrency)
+	dbConfig.RequestsPerSec = null.Int32From(apiConfig.RequestsPerSec)
+	dbConfig.MaxRetries = null.Int32FromPtr(apiConfig.MaxRetries)
+	dbConfig.RetryDelaySec = null.Int32From(apiConfig.RetryDelaySec)
+	dbConfig.BreakerThreshold = null.Int32From(apiConfig.BreakerThreshold)
+	dbConfig.BreakerProbeSec = null.Int32From(apiConfig.BreakerProbeSec)
 	dbConfig.AuthTimeout = apiConfig.AuthTimeout
 	dbConfig.IntervalSec = apiConfig.IntervalSec
 	dbConfig.RequestTimeout = apiConfig.RequestTimeout
 	if apiConfig.ProjIds != nil {
 		dbConfig.ProjIds = *apiConfig.ProjIds
 	}
+	if apiConfig.AttributeMappings != nil {
+		_ = dbConfig.AttributeMappings.Marshal(*apiConfig.AttributeMappings)
+	}
 	var fdsConfig types.JSON
 	_ = fdsConfig.Marshal(FdsConfig{
 		Name:       null.StringFromPtr(apiConfig.Username).String,
@@ -151,6 +214,86 @@ func getInactiveTimeout(config *dbhailo.Config) int32 {
 	}
 }
 
+func getRefreshInterval(config *dbhailo.Config) int32 {
+	if config.RefreshIntervalSec.Valid && config.RefreshIntervalSec.Int32 > 0 {
+		return config.RefreshIntervalSec.Int32
+	} else {
+		return DefaultRefreshInterval
+	}
+}
+
+func getConcurrency(config *dbhailo.Config) int32 {
+	if config.Concurrency.Valid && config.Concurrency.Int32 > 0 {
+		return config.Concurrency.Int32
+	} else {
+		return DefaultConcurrency
+	}
+}
+
+func getRequestsPerSec(config *dbhailo.Config) int32 {
+	if config.RequestsPerSec.Valid && config.RequestsPerSec.Int32 > 0 {
+		return config.RequestsPerSec.Int32
+	} else {
+		return DefaultRequestsPerSec
+	}
+}
+
+func getMaxRetries(config *dbhailo.Config) int32 {
+	if config.MaxRetries.Valid && config.MaxRetries.Int32 >= 0 {
+		return config.MaxRetries.Int32
+	} else {
+		return DefaultMaxRetries
+	}
+}
+
+func getRetryDelay(config *dbhailo.Config) int32 {
+	if config.RetryDelaySec.Valid && config.RetryDelaySec.Int32 > 0 {
+		return config.RetryDelaySec.Int32
+	} else {
+		return DefaultRetryDelay
+	}
+}
+
+func getBreakerThreshold(config *dbhailo.Config) int32 {
+	if config.BreakerThreshold.Valid && config.BreakerThreshold.Int32 > 0 {
+		return config.BreakerThreshold.Int32
+	} else {
+		return DefaultBreakerThreshold
+	}
+}
+
+func getBreakerProbe(config *dbhailo.Config) int32 {
+	if config.BreakerProbeSec.Valid && config.BreakerProbeSec.Int32 > 0 {
+		return config.BreakerProbeSec.Int32
+	} else {
+		return DefaultBreakerProbe
+	}
+}
+
+func getMinInterval(config *dbhailo.Config) int32 {
+	if config.MinIntervalSec.Valid && config.MinIntervalSec.Int32 > DefaultMinInterval {
+		return config.MinIntervalSec.Int32
+	} else {
+		return DefaultMinInterval
+	}
+}
+
+func getMaxInterval(config *dbhailo.Config) int32 {
+	if config.MaxIntervalSec.Valid && config.MaxIntervalSec.Int32 > 0 {
+		return config.MaxIntervalSec.Int32
+	} else {
+		return DefaultMaxInterval
+	}
+}
+
+func getUrgentFillLevel(config *dbhailo.Config) int32 {
+	if config.UrgentFillLevel.Valid && config.UrgentFillLevel.Int32 > 0 {
+		return config.UrgentFillLevel.Int32
+	} else {
+		return DefaultUrgentFillLevel
+	}
+}
+
 // GetConfig reads configured endpoints to a Hailo Digital Hub
 func GetConfig(ctx context.Context, configId int64) (*apiserver.Configuration, error) {
 	dbConfigs, err := dbhailo.Configs(dbhailo.ConfigWhere.AppID.EQ(configId)).All(ctx, db.Database(app.AppName()))
@@ -163,44 +306,164 @@ func GetConfig(ctx context.Context, configId int64) (*apiserver.Configuration, e
 	return apiConfigFromDbConfig(dbConfigs[0]), nil
 }
 
-// DeleteConfig reads configured endpoints to a Hailo Digital Hub
+// DeleteConfig deletes configured endpoints to a Hailo Digital Hub and removes the cached asset ids of the endpoint
 func DeleteConfig(ctx context.Context, configId int64) (int64, error) {
-	return dbhailo.Configs(dbhailo.ConfigWhere.AppID.EQ(configId)).DeleteAll(ctx, db.Database(app.AppName()))
+	count, err := dbhailo.Configs(dbhailo.ConfigWhere.AppID.EQ(configId)).DeleteAll(ctx, db.Database(app.AppName()))
+	if err != nil {
+		return count, err
+	}
+	uncacheAssetIds(configId)
+	return count, nil
 }
 
 // BuildFdsConfig create a config object with the given parameters and default values
 func BuildFdsConfig(authServer string, username string, password string, fdsEndpoint string) apiserver.Configuration {
 	config := apiserver.Configuration{
-		Username:       &username,
-		Password:       &password,
-		FdsServer:      &fdsEndpoint,
-		AuthServer:     &authServer,
-		Enable:         common.Ptr(true),
-		AuthTimeout:    5,
-		RequestTimeout: 60,
+		Username:           &username,
+		Password:           &password,
+		FdsServer:          &fdsEndpoint,
+		AuthServer:         &authServer,
+		Enable:             common.Ptr(true),
+		AuthTimeout:        5,
+		RequestTimeout:     60,
+		RefreshIntervalSec: DefaultRefreshInterval,
+		Concurrency:        DefaultConcurrency,
+		RequestsPerSec:     DefaultRequestsPerSec,
+		MaxRetries:         common.Ptr[int32](DefaultMaxRetries),
+		RetryDelaySec:      DefaultRetryDelay,
+		BreakerThreshold:   DefaultBreakerThreshold,
+		BreakerProbeSec:    DefaultBreakerProbe,
+		MinIntervalSec:     DefaultMinInterval,
+		MaxIntervalSec:     DefaultMaxInterval,
+		UrgentFillLevel:    DefaultUrgentFillLevel,
 	}
 	return config
 }
 
+// GetAssetId returns the Eliona asset id mapped to the device or nil if no mapping exists. The id is read from the
+// cache and only queried from the database, if the cache is not loaded.
 func GetAssetId(ctx context.Context, config apiserver.Configuration, projId string, deviceId string) (*int32, error) {
+	configId := null.Int64FromPtr(config.Id).Int64
+	if assetId, ok := cachedAssetId(configId, projId, deviceId); ok {
+		return assetId, nil
+	}
 	dbAssets, err := dbhailo.Assets(
-		dbhailo.AssetWhere.ConfigID.EQ(null.Int64FromPtr(config.Id).Int64),
+		dbhailo.AssetWhere.ConfigID.EQ(configId),
 		dbhailo.AssetWhere.ProjID.EQ(projId),
 		dbhailo.AssetWhere.DeviceID.EQ(deviceId),
 	).All(ctx, db.Database(app.AppName()))
 	if err != nil || len(dbAssets) == 0 {
 		return nil, err
 	}
+	cacheAssetId(configId, projId, deviceId, dbAssets[0].AssetID)
 	return common.Ptr(dbAssets[0].AssetID), nil
 }
 
+// InsertAsset stores the mapping of the device to the Eliona asset and caches the asset id
 func InsertAsset(ctx context.Context, config apiserver.Configuration, projId string, deviceId string, assetId int32) error {
 	var dbAsset dbhailo.Asset
 	dbAsset.ConfigID = null.Int64FromPtr(config.Id).Int64
 	dbAsset.ProjID = projId
 	dbAsset.DeviceID = deviceId
 	dbAsset.AssetID = assetId
-	return dbAsset.Insert(ctx, db.Database(app.AppName()), boil.Infer())
+	err := dbAsset.Insert(ctx, db.Database(app.AppName()), boil.Infer())
+	if err != nil {
+		return err
+	}
+	cacheAssetId(dbAsset.ConfigID, projId, deviceId, assetId)
+	return nil
+}
+
+// SetDeviceLocation overrides the location of the device in all asset mappings of the device and returns the
+// updated asset mappings. If no asset is mapped to the device, no asset mappings are returned.
+func SetDeviceLocation(ctx context.Context, configId int64, deviceId string, location apiserver.Location) ([]apiserver.AssetMapping, error) {
+	if err := CheckLocation(location); err != nil {
+		return nil, err
+	}
+	_, err := dbhailo.Assets(
+		dbhailo.AssetWhere.ConfigID.EQ(configId),
+		dbhailo.AssetWhere.DeviceID.EQ(deviceId),
+	).UpdateAll(ctx, db.Database(app.AppName()), dbhailo.M{
+		dbhailo.AssetColumns.Latitude:  null.Float64FromPtr(location.Latitude),
+		dbhailo.AssetColumns.Longitude: null.Float64FromPtr(location.Longitude),
+		dbhailo.AssetColumns.Address:   null.StringFromPtr(location.Address),
+	})
+	if err != nil {
+		return nil, err
+	}
+	dbAssetMappings, err := dbhailo.Assets(
+		dbhailo.AssetWhere.ConfigID.EQ(configId),
+		dbhailo.AssetWhere.DeviceID.EQ(deviceId),
+	).All(ctx, db.Database(app.AppName()))
+	if err != nil {
+		return nil, err
+	}
+	var apiAssetMappings []apiserver.AssetMapping
+	for _, dbAssetMapping := range dbAssetMappings {
+		apiAssetMappings = append(apiAssetMappings, *apiAssetMappingFromDbAssetMapping(dbAssetMapping))
+	}
+	if len(apiAssetMappings) > 0 {
+		cacheLocation(configId, deviceId, assetMappingLocation(apiAssetMappings[0]))
+	}
+	return apiAssetMappings, nil
+}
+
+// GetAssetTags returns the tags last set by the app for the asset or nil, if the app has not set tags yet
+func GetAssetTags(ctx context.Context, assetId int32) ([]string, error) {
+	dbAssets, err := dbhailo.Assets(
+		dbhailo.AssetWhere.AssetID.EQ(assetId),
+	).All(ctx, db.Database(app.AppName()))
+	if err != nil || len(dbAssets) == 0 {
+		return nil, err
+	}
+	return dbAssets[0].Tags, nil
+}
+
+// SetAssetTags stores the tags set by the app for the asset, so they can be replaced after a restart of the app
+func SetAssetTags(ctx context.Context, assetId int32, tags []string) error {
+	if tags == nil {
+		tags = []string{}
+	}
+	_, err := dbhailo.Assets(
+		dbhailo.AssetWhere.AssetID.EQ(assetId),
+	).UpdateAll(ctx, db.Database(app.AppName()), dbhailo.M{
+		dbhailo.AssetColumns.Tags: types.StringArray(tags),
+	})
+	return err
+}
+
+// CheckLocation returns an error, if the coordinates of the location are out of range or only one of latitude and
+// longitude is set
+func CheckLocation(location apiserver.Location) error {
+	if (location.Latitude == nil) != (location.Longitude == nil) {
+		return fmt.Errorf("latitude and longitude must be set both or none")
+	}
+	if location.Latitude != nil && (*location.Latitude < -90 || *location.Latitude > 90) {
+		return fmt.Errorf("invalid latitude %v", *location.Latitude)
+	}
+	if location.Longitude != nil && (*location.Longitude < -180 || *location.Longitude > 180) {
+		return fmt.Errorf("invalid longitude %v", *location.Longitude)
+	}
+	return nil
+}
+
+// GetDeviceLocation returns the location override of the device or nil if no override exists. The location is read
+// from the cache and only queried from the database, if the cache is not loaded.
+func GetDeviceLocation(ctx context.Context, config apiserver.Configuration, deviceId string) (*apiserver.Location, error) {
+	configId := null.Int64FromPtr(config.Id).Int64
+	if location, ok := cachedLocation(configId, deviceId); ok {
+		return location, nil
+	}
+	dbAssets, err := dbhailo.Assets(
+		dbhailo.AssetWhere.ConfigID.EQ(configId),
+		dbhailo.AssetWhere.DeviceID.EQ(deviceId),
+	).All(ctx, db.Database(app.AppName()))
+	if err != nil || len(dbAssets) == 0 {
+		return nil, err
+	}
+	location := assetMappingLocation(*apiAssetMappingFromDbAssetMapping(dbAssets[0]))
+	cacheLocation(configId, deviceId, location)
+	return location, nil
 }
 
 func SetConfigActiveState(ctx context.Context, config apiserver.Configuration, state bool) (int64, error) {
@@ -211,6 +474,14 @@ func SetConfigActiveState(ctx context.Context, config apiserver.Configuration, s
 	})
 }
 
+func SetConfigCircuitState(ctx context.Context, config apiserver.Configuration, state string) (int64, error) {
+	return dbhailo.Configs(
+		dbhailo.ConfigWhere.AppID.EQ(null.Int64FromPtr(config.Id).Int64),
+	).UpdateAll(ctx, db.Database(app.AppName()), dbhailo.M{
+		dbhailo.ConfigColumns.CircuitState: state,
+	})
+}
+
 func ProjIds(config apiserver.Configuration) []string {
 	if config.ProjIds == nil {
 		return []string{}
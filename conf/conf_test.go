@@ -0,0 +1,33 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"hailo/apiserver"
+	"testing"
+)
+
+func TestCheckLocation(t *testing.T) {
+	assert.Nil(t, CheckLocation(apiserver.Location{}))
+	assert.Nil(t, CheckLocation(apiserver.Location{Address: common.Ptr("Paradeplatz")}))
+	assert.Nil(t, CheckLocation(apiserver.Location{Latitude: common.Ptr(-90.0), Longitude: common.Ptr(180.0)}))
+	assert.NotNil(t, CheckLocation(apiserver.Location{Latitude: common.Ptr(47.37)}))
+	assert.NotNil(t, CheckLocation(apiserver.Location{Longitude: common.Ptr(8.54)}))
+	assert.NotNil(t, CheckLocation(apiserver.Location{Latitude: common.Ptr(90.1), Longitude: common.Ptr(8.54)}))
+	assert.NotNil(t, CheckLocation(apiserver.Location{Latitude: common.Ptr(47.37), Longitude: common.Ptr(-180.1)}))
+}
@@ -19,17 +19,31 @@ create schema if not exists hailo;
 -- This table should be made editable by eliona frontend.
 create table if not exists hailo.config
 (
-    app_id           bigserial primary key,
-    config           json      not null,
-    enable           boolean   default false,
-    description      text,
-    asset_id         integer,
-    interval_sec     integer not null,
-    auth_timeout     integer not null default 5,
-    request_timeout  integer not null default 120,
-    inactive_timeout integer,
-    active           boolean default false,
-    proj_ids         text[]
+    app_id               bigserial primary key,
+    config               json      not null,
+    enable               boolean   default false,
+    description          text,
+    asset_id             integer,
+    interval_sec         integer not null,
+    auth_timeout         integer not null default 5,
+    request_timeout      integer not null default 120,
+    inactive_timeout     integer,
+    active               boolean default false,
+    proj_ids             text[],
+    refresh_interval_sec integer,
+    concurrency          integer,
+    requests_per_sec     integer,
+    max_retries          integer,
+    retry_delay_sec      integer,
+    breaker_threshold    integer,
+    breaker_probe_sec    integer,
+    circuit_state        text,
+    attribute_mappings   json,
+    content_category_asset_types boolean,
+    adaptive_polling     boolean,
+    min_interval_sec     integer,
+    max_interval_sec     integer,
+    urgent_fill_level    integer
 );
 
 -- Makes the new objects available for all other init steps
@@ -0,0 +1,114 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"context"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/app"
+	"github.com/eliona-smart-building-assistant/go-utils/db"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	dbhailo "hailo/db/hailo"
+)
+
+// QueuedData is data which could not be written to Eliona and waits in the outbox
+type QueuedData struct {
+	Id   int64
+	Data api.Data
+}
+
+// EnqueueData stores data in the outbox. Already queued data for the same asset, subtype and timestamp is replaced.
+// Data without timestamp is queued without timestamp and replaces queued data of the same asset and subtype which has
+// no timestamp either.
+func EnqueueData(ctx context.Context, data api.Data) error {
+	var dbOutbox dbhailo.Outbox
+	err := dbOutbox.Data.Marshal(data.Data)
+	if err != nil {
+		return err
+	}
+	conflict := "(asset_id, subtype, timestamp)"
+	if data.Timestamp.Get() == nil {
+		conflict = "(asset_id, subtype) where timestamp is null"
+	} else {
+		dbOutbox.Timestamp = null.TimeFrom(data.Timestamp.Get().UTC())
+	}
+	_, err = queries.Raw(`
+		insert into hailo.outbox (asset_id, subtype, timestamp, data)
+		values ($1, $2, $3, $4)
+		on conflict `+conflict+` do update
+		set data = excluded.data`,
+		data.AssetId, string(data.Subtype), dbOutbox.Timestamp, dbOutbox.Data,
+	).ExecContext(ctx, db.Database(app.AppName()))
+	return err
+}
+
+// GetQueuedData reads the oldest data from the outbox ordered by timestamp, data without timestamp by the time it was
+// queued. The first data up to the offset are skipped.
+func GetQueuedData(ctx context.Context, offset int, limit int) ([]QueuedData, error) {
+	dbOutboxes, err := dbhailo.Outboxes(
+		qm.OrderBy("coalesce("+dbhailo.OutboxColumns.Timestamp+", "+dbhailo.OutboxColumns.CreatedAt+"), "+dbhailo.OutboxColumns.ID),
+		qm.Offset(offset),
+		qm.Limit(limit),
+	).All(ctx, db.Database(app.AppName()))
+	if err != nil {
+		return nil, err
+	}
+	var queuedData []QueuedData
+	for _, dbOutbox := range dbOutboxes {
+		queuedData = append(queuedData, QueuedData{Id: dbOutbox.ID, Data: apiDataFromDbOutbox(dbOutbox)})
+	}
+	return queuedData, nil
+}
+
+// CountQueuedData returns the number of data waiting in the outbox
+func CountQueuedData(ctx context.Context) (int64, error) {
+	return dbhailo.Outboxes().Count(ctx, db.Database(app.AppName()))
+}
+
+// AddFailedAttempt counts a failed attempt to write the queued data and returns the number of failed attempts
+func AddFailedAttempt(ctx context.Context, id int64) (int32, error) {
+	dbOutbox, err := dbhailo.FindOutbox(ctx, db.Database(app.AppName()), id)
+	if err != nil {
+		return 0, err
+	}
+	dbOutbox.Attempts++
+	_, err = dbOutbox.Update(ctx, db.Database(app.AppName()), boil.Whitelist(dbhailo.OutboxColumns.Attempts))
+	return dbOutbox.Attempts, err
+}
+
+// DeleteQueuedData removes data from the outbox after it is written to Eliona
+func DeleteQueuedData(ctx context.Context, id int64) (int64, error) {
+	return dbhailo.Outboxes(dbhailo.OutboxWhere.ID.EQ(id)).DeleteAll(ctx, db.Database(app.AppName()))
+}
+
+// DeleteExpiredQueuedData removes data from the outbox which was queued before the given time
+func DeleteExpiredQueuedData(ctx context.Context, before time.Time) (int64, error) {
+	return dbhailo.Outboxes(dbhailo.OutboxWhere.CreatedAt.LT(before)).DeleteAll(ctx, db.Database(app.AppName()))
+}
+
+func apiDataFromDbOutbox(dbOutbox *dbhailo.Outbox) api.Data {
+	var apiData api.Data
+	apiData.AssetId = dbOutbox.AssetID
+	apiData.Subtype = api.DataSubtype(dbOutbox.Subtype)
+	apiData.Timestamp = *api.NewNullableTime(dbOutbox.Timestamp.Ptr())
+	_ = dbOutbox.Data.Unmarshal(&apiData.Data)
+	return apiData
+}
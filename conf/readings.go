@@ -0,0 +1,224 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"context"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-eliona/app"
+	"github.com/eliona-smart-building-assistant/go-utils/db"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	dbhailo "hailo/db/hailo"
+)
+
+// Reading is the state of a device at its last contact, kept as history for analyses like service level reports
+type Reading struct {
+	ConfigId        int64
+	DeviceId        string
+	StationId       string
+	ContentCategory string
+	BinVolume       *int32
+	Timestamp       time.Time
+	Volumepercent   *float64
+	Openings        *int32
+	LastService     *time.Time
+}
+
+// BatteryReading is the battery level of a device at its last contact, kept as history to forecast the depletion
+type BatteryReading struct {
+	ConfigId  int64
+	DeviceId  string
+	Kind      string
+	StationId string
+	Timestamp time.Time
+	BatLevel  float64
+}
+
+// InsertReading stores the reading in the history. A reading already stored for the device and timestamp is kept.
+func InsertReading(ctx context.Context, reading Reading) error {
+	dbReading := dbReadingFromReading(reading)
+	return dbReading.Upsert(ctx, db.Database(app.AppName()), false,
+		[]string{dbhailo.ReadingColumns.ConfigID, dbhailo.ReadingColumns.DeviceID, dbhailo.ReadingColumns.Timestamp},
+		boil.None(),
+		boil.Blacklist(dbhailo.ReadingColumns.ID),
+	)
+}
+
+// GetReadings reads the history of all devices of the configuration between from and to ordered by device and
+// timestamp
+func GetReadings(ctx context.Context, configId int64, from time.Time, to time.Time) ([]Reading, error) {
+	dbReadings, err := dbhailo.Readings(
+		dbhailo.ReadingWhere.ConfigID.EQ(configId),
+		dbhailo.ReadingWhere.Timestamp.GTE(from),
+		dbhailo.ReadingWhere.Timestamp.LT(to),
+		qm.OrderBy(dbhailo.ReadingColumns.DeviceID+", "+dbhailo.ReadingColumns.Timestamp),
+	).All(ctx, db.Database(app.AppName()))
+	if err != nil {
+		return nil, err
+	}
+	var readings []Reading
+	for _, dbReading := range dbReadings {
+		readings = append(readings, readingFromDbReading(dbReading))
+	}
+	return readings, nil
+}
+
+// GetDeviceReadings reads the history of the device since the given time ordered by timestamp
+func GetDeviceReadings(ctx context.Context, configId int64, deviceId string, since time.Time) ([]Reading, error) {
+	dbReadings, err := dbhailo.Readings(
+		dbhailo.ReadingWhere.ConfigID.EQ(configId),
+		dbhailo.ReadingWhere.DeviceID.EQ(deviceId),
+		dbhailo.ReadingWhere.Timestamp.GTE(since),
+		qm.OrderBy(dbhailo.ReadingColumns.Timestamp),
+	).All(ctx, db.Database(app.AppName()))
+	if err != nil {
+		return nil, err
+	}
+	var readings []Reading
+	for _, dbReading := range dbReadings {
+		readings = append(readings, readingFromDbReading(dbReading))
+	}
+	return readings, nil
+}
+
+// GetLastReadings reads the last reading of each device of the configuration before the given time ordered by device
+func GetLastReadings(ctx context.Context, configId int64, before time.Time) ([]Reading, error) {
+	dbReadings, err := dbhailo.Readings(
+		qm.Select("distinct on ("+dbhailo.ReadingColumns.DeviceID+") *"),
+		dbhailo.ReadingWhere.ConfigID.EQ(configId),
+		dbhailo.ReadingWhere.Timestamp.LT(before),
+		qm.OrderBy(dbhailo.ReadingColumns.DeviceID+", "+dbhailo.ReadingColumns.Timestamp+" desc"),
+	).All(ctx, db.Database(app.AppName()))
+	if err != nil {
+		return nil, err
+	}
+	var readings []Reading
+	for _, dbReading := range dbReadings {
+		readings = append(readings, readingFromDbReading(dbReading))
+	}
+	return readings, nil
+}
+
+// InsertBatteryReading stores the battery level of the device in the history. A level already stored for the device
+// and timestamp is kept.
+func InsertBatteryReading(ctx context.Context, reading BatteryReading) error {
+	dbReading := dbhailo.BatteryReading{
+		ConfigID:  reading.ConfigId,
+		DeviceID:  reading.DeviceId,
+		Kind:      reading.Kind,
+		StationID: null.NewString(reading.StationId, reading.StationId != ""),
+		Timestamp: reading.Timestamp.UTC(),
+		BatLevel:  reading.BatLevel,
+	}
+	return dbReading.Upsert(ctx, db.Database(app.AppName()), false,
+		[]string{dbhailo.BatteryReadingColumns.ConfigID, dbhailo.BatteryReadingColumns.DeviceID, dbhailo.BatteryReadingColumns.Timestamp},
+		boil.None(),
+		boil.Blacklist(dbhailo.BatteryReadingColumns.ID),
+	)
+}
+
+// GetBatteryReadings reads the battery history of the device since the given time ordered by timestamp
+func GetBatteryReadings(ctx context.Context, configId int64, deviceId string, since time.Time) ([]BatteryReading, error) {
+	dbReadings, err := dbhailo.BatteryReadings(
+		dbhailo.BatteryReadingWhere.ConfigID.EQ(configId),
+		dbhailo.BatteryReadingWhere.DeviceID.EQ(deviceId),
+		dbhailo.BatteryReadingWhere.Timestamp.GTE(since),
+		qm.OrderBy(dbhailo.BatteryReadingColumns.Timestamp),
+	).All(ctx, db.Database(app.AppName()))
+	if err != nil {
+		return nil, err
+	}
+	var readings []BatteryReading
+	for _, dbReading := range dbReadings {
+		readings = append(readings, batteryReadingFromDbBatteryReading(dbReading))
+	}
+	return readings, nil
+}
+
+// GetConfigBatteryReadings reads the battery history of all devices of the configuration since the given time ordered
+// by device and timestamp
+func GetConfigBatteryReadings(ctx context.Context, configId int64, since time.Time) ([]BatteryReading, error) {
+	dbReadings, err := dbhailo.BatteryReadings(
+		dbhailo.BatteryReadingWhere.ConfigID.EQ(configId),
+		dbhailo.BatteryReadingWhere.Timestamp.GTE(since),
+		qm.OrderBy(dbhailo.BatteryReadingColumns.DeviceID+", "+dbhailo.BatteryReadingColumns.Timestamp),
+	).All(ctx, db.Database(app.AppName()))
+	if err != nil {
+		return nil, err
+	}
+	var readings []BatteryReading
+	for _, dbReading := range dbReadings {
+		readings = append(readings, batteryReadingFromDbBatteryReading(dbReading))
+	}
+	return readings, nil
+}
+
+// DeleteExpiredReadings removes readings, battery levels and hourly usages from the history which are older than the
+// given time
+func DeleteExpiredReadings(ctx context.Context, before time.Time) (int64, error) {
+	deleted, err := dbhailo.Readings(dbhailo.ReadingWhere.Timestamp.LT(before)).DeleteAll(ctx, db.Database(app.AppName()))
+	if err != nil {
+		return 0, err
+	}
+	deletedBatteries, err := dbhailo.BatteryReadings(dbhailo.BatteryReadingWhere.Timestamp.LT(before)).DeleteAll(ctx, db.Database(app.AppName()))
+	if err != nil {
+		return deleted, err
+	}
+	deletedUsages, err := dbhailo.Usages(dbhailo.UsageWhere.Hour.LT(before)).DeleteAll(ctx, db.Database(app.AppName()))
+	return deleted + deletedBatteries + deletedUsages, err
+}
+
+func dbReadingFromReading(reading Reading) dbhailo.Reading {
+	var dbReading dbhailo.Reading
+	dbReading.ConfigID = reading.ConfigId
+	dbReading.DeviceID = reading.DeviceId
+	dbReading.StationID = null.NewString(reading.StationId, reading.StationId != "")
+	dbReading.ContentCategory = null.NewString(reading.ContentCategory, reading.ContentCategory != "")
+	dbReading.BinVolume = null.Int32FromPtr(reading.BinVolume)
+	dbReading.Timestamp = reading.Timestamp.UTC()
+	dbReading.Volumepercent = null.Float64FromPtr(reading.Volumepercent)
+	dbReading.Openings = null.Int32FromPtr(reading.Openings)
+	dbReading.LastService = null.TimeFromPtr(reading.LastService)
+	return dbReading
+}
+
+func readingFromDbReading(dbReading *dbhailo.Reading) Reading {
+	var reading Reading
+	reading.ConfigId = dbReading.ConfigID
+	reading.DeviceId = dbReading.DeviceID
+	reading.StationId = dbReading.StationID.String
+	reading.ContentCategory = dbReading.ContentCategory.String
+	reading.BinVolume = dbReading.BinVolume.Ptr()
+	reading.Timestamp = dbReading.Timestamp
+	reading.Volumepercent = dbReading.Volumepercent.Ptr()
+	reading.Openings = dbReading.Openings.Ptr()
+	reading.LastService = dbReading.LastService.Ptr()
+	return reading
+}
+
+func batteryReadingFromDbBatteryReading(dbReading *dbhailo.BatteryReading) BatteryReading {
+	var reading BatteryReading
+	reading.ConfigId = dbReading.ConfigID
+	reading.DeviceId = dbReading.DeviceID
+	reading.Kind = dbReading.Kind
+	reading.StationId = dbReading.StationID.String
+	reading.Timestamp = dbReading.Timestamp
+	reading.BatLevel = dbReading.BatLevel
+	return reading
+}
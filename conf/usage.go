@@ -0,0 +1,118 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"context"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-eliona/app"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/db"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	dbhailo "hailo/db/hailo"
+)
+
+// Usage is the number of openings of a bin or station within an hour. The total openings counter and the last
+// contact of the last reading within the hour are kept to derive the openings of the next reading.
+type Usage struct {
+	ConfigId      int64
+	DeviceId      string
+	Kind          string
+	StationId     string
+	Hour          time.Time
+	Openings      int32
+	TotalOpenings int32
+	LastContact   time.Time
+}
+
+// GetLastUsage reads the usage of the last hour stored for the device or nil, if no usage is stored
+func GetLastUsage(ctx context.Context, configId int64, deviceId string) (*Usage, error) {
+	dbUsages, err := dbhailo.Usages(
+		dbhailo.UsageWhere.ConfigID.EQ(configId),
+		dbhailo.UsageWhere.DeviceID.EQ(deviceId),
+		qm.OrderBy(dbhailo.UsageColumns.Hour+" desc"),
+		qm.Limit(1),
+	).All(ctx, db.Database(app.AppName()))
+	if err != nil || len(dbUsages) == 0 {
+		return nil, err
+	}
+	return common.Ptr(usageFromDbUsage(dbUsages[0])), nil
+}
+
+// AddUsage adds the openings to the usage of the device in the hour. The total openings counter and the last contact
+// are replaced. The usage is inserted or updated by a single statement, so concurrent writers cannot lose openings.
+func AddUsage(ctx context.Context, usage Usage) error {
+	dbUsage := dbUsageFromUsage(usage)
+	_, err := queries.Raw(`
+		insert into hailo.usage (config_id, device_id, kind, station_id, hour, openings, total_openings, last_contact)
+		values ($1, $2, $3, $4, $5, $6, $7, $8)
+		on conflict (config_id, device_id, hour) do update
+		set openings = usage.openings + excluded.openings,
+		    total_openings = excluded.total_openings,
+		    last_contact = excluded.last_contact`,
+		dbUsage.ConfigID, dbUsage.DeviceID, dbUsage.Kind, dbUsage.StationID, dbUsage.Hour,
+		dbUsage.Openings, dbUsage.TotalOpenings, dbUsage.LastContact,
+	).ExecContext(ctx, db.Database(app.AppName()))
+	return err
+}
+
+// GetUsages reads the usage of all devices of the configuration of the hours between from and to ordered by device
+// and hour
+func GetUsages(ctx context.Context, configId int64, from time.Time, to time.Time) ([]Usage, error) {
+	dbUsages, err := dbhailo.Usages(
+		dbhailo.UsageWhere.ConfigID.EQ(configId),
+		dbhailo.UsageWhere.Hour.GTE(from),
+		dbhailo.UsageWhere.Hour.LT(to),
+		qm.OrderBy(dbhailo.UsageColumns.DeviceID+", "+dbhailo.UsageColumns.Hour),
+	).All(ctx, db.Database(app.AppName()))
+	if err != nil {
+		return nil, err
+	}
+	var usages []Usage
+	for _, dbUsage := range dbUsages {
+		usages = append(usages, usageFromDbUsage(dbUsage))
+	}
+	return usages, nil
+}
+
+func dbUsageFromUsage(usage Usage) dbhailo.Usage {
+	var dbUsage dbhailo.Usage
+	dbUsage.ConfigID = usage.ConfigId
+	dbUsage.DeviceID = usage.DeviceId
+	dbUsage.Kind = usage.Kind
+	dbUsage.StationID = null.NewString(usage.StationId, usage.StationId != "")
+	dbUsage.Hour = usage.Hour.UTC()
+	dbUsage.Openings = usage.Openings
+	dbUsage.TotalOpenings = usage.TotalOpenings
+	dbUsage.LastContact = usage.LastContact.UTC()
+	return dbUsage
+}
+
+func usageFromDbUsage(dbUsage *dbhailo.Usage) Usage {
+	var usage Usage
+	usage.ConfigId = dbUsage.ConfigID
+	usage.DeviceId = dbUsage.DeviceID
+	usage.Kind = dbUsage.Kind
+	usage.StationId = dbUsage.StationID.String
+	usage.Hour = dbUsage.Hour
+	usage.Openings = dbUsage.Openings
+	usage.TotalOpenings = dbUsage.TotalOpenings
+	usage.LastContact = dbUsage.LastContact
+	return usage
+}
@@ -0,0 +1,120 @@
+--  This file is part of the eliona project.
+--  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+--  ______ _ _
+-- |  ____| (_)
+-- | |__  | |_  ___  _ __   __ _
+-- |  __| | | |/ _ \| '_ \ / _` |
+-- | |____| | | (_) | | | | (_| |
+-- |______|_|_|\___/|_| |_|\__,_|
+--
+--  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+--  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+--  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+--  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+--  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+-- Interval after which all data is written to Eliona again, even if nothing has changed since
+-- the last write.
+alter table hailo.config add column if not exists refresh_interval_sec integer;
+
+-- Number of devices processed in parallel and the maximum number of requests per second
+-- sent to the FDS host.
+alter table hailo.config add column if not exists concurrency integer;
+alter table hailo.config add column if not exists requests_per_sec integer;
+
+-- Retries of failed FDS requests and the circuit breaker, which stops requesting an unreachable
+-- FDS endpoint. The current state of the circuit breaker is set by the app.
+alter table hailo.config add column if not exists max_retries integer;
+alter table hailo.config add column if not exists retry_delay_sec integer;
+alter table hailo.config add column if not exists breaker_threshold integer;
+alter table hailo.config add column if not exists breaker_probe_sec integer;
+alter table hailo.config add column if not exists circuit_state text;
+
+-- Mappings of FDS values to asset attributes, which override or extend the default mappings of the app.
+alter table hailo.config add column if not exists attribute_mappings json;
+
+-- Create bins with an asset type specific for the content category like paper or PET.
+alter table hailo.config add column if not exists content_category_asset_types boolean;
+
+-- Adaptive polling, which reads the status of nearly full bins and devices with alarms more often and of idle
+-- devices less often, bounded by the minimum and maximum interval.
+alter table hailo.config add column if not exists adaptive_polling boolean;
+alter table hailo.config add column if not exists min_interval_sec integer;
+alter table hailo.config add column if not exists max_interval_sec integer;
+alter table hailo.config add column if not exists urgent_fill_level integer;
+
+-- Location of a device which overrides the location read from the device specification.
+alter table hailo.asset add column if not exists latitude double precision;
+alter table hailo.asset add column if not exists longitude double precision;
+alter table hailo.asset add column if not exists address text;
+
+-- Tags last set by the app for the asset, which are replaced by the app if the device specification changes.
+alter table hailo.asset add column if not exists tags text[];
+
+-- Create table to queue data which could not be written to Eliona, e.g. during an outage of Eliona.
+-- The queued data is written in order of the timestamps as soon as Eliona is reachable again. Data which fails
+-- repeatedly while other data is written is discarded after a number of attempts. Data without timestamp is queued
+-- with a null timestamp and only the latest data of an asset and subtype is kept.
+create table if not exists hailo.outbox
+(
+    id              bigserial primary key,
+    asset_id        integer not null,
+    subtype         text not null,
+    timestamp       timestamp with time zone,
+    data            json not null,
+    created_at      timestamp with time zone not null default now(),
+    attempts        integer not null default 0,
+    unique (asset_id, subtype, timestamp)
+);
+create unique index if not exists outbox_without_timestamp_key on hailo.outbox (asset_id, subtype) where timestamp is null;
+
+-- Create table to keep the history of the readings of bins, e.g. for service level reports and right-sizing. A
+-- reading is stored for each new last contact of a device and deleted after the retention time.
+create table if not exists hailo.reading
+(
+    id               bigserial primary key,
+    config_id        bigint not null,
+    device_id        text not null,
+    station_id       text,
+    content_category text,
+    bin_volume       integer,
+    timestamp        timestamp with time zone not null,
+    volumepercent    double precision,
+    openings         integer,
+    last_service     timestamp with time zone,
+    unique (config_id, device_id, timestamp)
+);
+
+-- Create table to keep the history of the battery levels of bins and stations to forecast when the batteries are
+-- depleted. A level is stored for each new last contact of a device and deleted after the retention time. The kind
+-- and the station of the device are kept to group the battery replacements.
+create table if not exists hailo.battery_reading
+(
+    id         bigserial primary key,
+    config_id  bigint not null,
+    device_id  text not null,
+    kind       text not null,
+    station_id text,
+    timestamp  timestamp with time zone not null,
+    bat_level  double precision not null,
+    unique (config_id, device_id, timestamp)
+);
+
+-- Create table to aggregate the openings of bins and stations per hour, e.g. for usage heatmaps. The openings are
+-- derived from the total openings counter of successive readings, which is kept with the last contact of the hour.
+create table if not exists hailo.usage
+(
+    id             bigserial primary key,
+    config_id      bigint not null,
+    device_id      text not null,
+    kind           text not null,
+    station_id     text,
+    hour           timestamp with time zone not null,
+    openings       integer not null,
+    total_openings integer not null,
+    last_contact   timestamp with time zone not null,
+    unique (config_id, device_id, hour)
+);
+
+-- Makes the new objects available for all other init steps
+commit;
@@ -14,46 +14,68 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
 	"github.com/volatiletech/strmangle"
 )
 
 // Asset is an object representing the database table.
 type Asset struct {
-	ConfigID int64  `boil:"config_id" json:"config_id" toml:"config_id" yaml:"config_id"`
-	DeviceID string `boil:"device_id" json:"device_id" toml:"device_id" yaml:"device_id"`
-	ProjID   string `boil:"proj_id" json:"proj_id" toml:"proj_id" yaml:"proj_id"`
-	AssetID  int32  `boil:"asset_id" json:"asset_id" toml:"asset_id" yaml:"asset_id"`
+	ConfigID  int64             `boil:"config_id" json:"config_id" toml:"config_id" yaml:"config_id"`
+	DeviceID  string            `boil:"device_id" json:"device_id" toml:"device_id" yaml:"device_id"`
+	ProjID    string            `boil:"proj_id" json:"proj_id" toml:"proj_id" yaml:"proj_id"`
+	AssetID   int32             `boil:"asset_id" json:"asset_id" toml:"asset_id" yaml:"asset_id"`
+	Latitude  null.Float64      `boil:"latitude" json:"latitude,omitempty" toml:"latitude" yaml:"latitude,omitempty"`
+	Longitude null.Float64      `boil:"longitude" json:"longitude,omitempty" toml:"longitude" yaml:"longitude,omitempty"`
+	Address   null.String       `boil:"address" json:"address,omitempty" toml:"address" yaml:"address,omitempty"`
+	Tags      types.StringArray `boil:"tags" json:"tags,omitempty" toml:"tags" yaml:"tags,omitempty"`
 
 	R *assetR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L assetL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var AssetColumns = struct {
-	ConfigID string
-	DeviceID string
-	ProjID   string
-	AssetID  string
+	ConfigID  string
+	DeviceID  string
+	ProjID    string
+	AssetID   string
+	Latitude  string
+	Longitude string
+	Address   string
+	Tags      string
 }{
-	ConfigID: "config_id",
-	DeviceID: "device_id",
-	ProjID:   "proj_id",
-	AssetID:  "asset_id",
+	ConfigID:  "config_id",
+	DeviceID:  "device_id",
+	ProjID:    "proj_id",
+	AssetID:   "asset_id",
+	Latitude:  "latitude",
+	Longitude: "longitude",
+	Address:   "address",
+	Tags:      "tags",
 }
 
 var AssetTableColumns = struct {
-	ConfigID string
-	DeviceID string
-	ProjID   string
-	AssetID  string
+	ConfigID  string
+	DeviceID  string
+	ProjID    string
+	AssetID   string
+	Latitude  string
+	Longitude string
+	Address   string
+	Tags      string
 }{
-	ConfigID: "asset.config_id",
-	DeviceID: "asset.device_id",
-	ProjID:   "asset.proj_id",
-	AssetID:  "asset.asset_id",
+	ConfigID:  "asset.config_id",
+	DeviceID:  "asset.device_id",
+	ProjID:    "asset.proj_id",
+	AssetID:   "asset.asset_id",
+	Latitude:  "asset.latitude",
+	Longitude: "asset.longitude",
+	Address:   "asset.address",
+	Tags:      "asset.tags",
 }
 
 // Generated where
@@ -127,16 +149,100 @@ func (w whereHelperint32) NIN(slice []int32) qm.QueryMod {
 	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
 }
 
+type whereHelpernull_Float64 struct{ field string }
+
+func (w whereHelpernull_Float64) EQ(x null.Float64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Float64) NEQ(x null.Float64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Float64) LT(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Float64) LTE(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Float64) GT(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Float64) GTE(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Float64) IN(slice []float64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Float64) NIN(slice []float64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Float64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Float64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_String struct{ field string }
+
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var AssetWhere = struct {
-	ConfigID whereHelperint64
-	DeviceID whereHelperstring
-	ProjID   whereHelperstring
-	AssetID  whereHelperint32
+	ConfigID  whereHelperint64
+	DeviceID  whereHelperstring
+	ProjID    whereHelperstring
+	AssetID   whereHelperint32
+	Latitude  whereHelpernull_Float64
+	Longitude whereHelpernull_Float64
+	Address   whereHelpernull_String
+	Tags      whereHelpertypes_StringArray
 }{
-	ConfigID: whereHelperint64{field: "\"hailo\".\"asset\".\"config_id\""},
-	DeviceID: whereHelperstring{field: "\"hailo\".\"asset\".\"device_id\""},
-	ProjID:   whereHelperstring{field: "\"hailo\".\"asset\".\"proj_id\""},
-	AssetID:  whereHelperint32{field: "\"hailo\".\"asset\".\"asset_id\""},
+	ConfigID:  whereHelperint64{field: "\"hailo\".\"asset\".\"config_id\""},
+	DeviceID:  whereHelperstring{field: "\"hailo\".\"asset\".\"device_id\""},
+	ProjID:    whereHelperstring{field: "\"hailo\".\"asset\".\"proj_id\""},
+	AssetID:   whereHelperint32{field: "\"hailo\".\"asset\".\"asset_id\""},
+	Latitude:  whereHelpernull_Float64{field: "\"hailo\".\"asset\".\"latitude\""},
+	Longitude: whereHelpernull_Float64{field: "\"hailo\".\"asset\".\"longitude\""},
+	Address:   whereHelpernull_String{field: "\"hailo\".\"asset\".\"address\""},
+	Tags:      whereHelpertypes_StringArray{field: "\"hailo\".\"asset\".\"tags\""},
 }
 
 // AssetRels is where relationship names are stored.
@@ -156,9 +262,9 @@ func (*assetR) NewStruct() *assetR {
 type assetL struct{}
 
 var (
-	assetAllColumns            = []string{"config_id", "device_id", "proj_id", "asset_id"}
+	assetAllColumns            = []string{"config_id", "device_id", "proj_id", "asset_id", "latitude", "longitude", "address", "tags"}
 	assetColumnsWithoutDefault = []string{"config_id", "device_id", "proj_id", "asset_id"}
-	assetColumnsWithDefault    = []string{}
+	assetColumnsWithDefault    = []string{"latitude", "longitude", "address", "tags"}
 	assetPrimaryKeyColumns     = []string{"config_id", "device_id", "proj_id", "asset_id"}
 	assetGeneratedColumns      = []string{}
 )
@@ -4,9 +4,17 @@
 package dbhailo
 
 var TableNames = struct {
-	Asset  string
-	Config string
+	Asset          string
+	BatteryReading string
+	Config         string
+	Outbox         string
+	Reading        string
+	Usage          string
 }{
-	Asset:  "asset",
-	Config: "config",
+	Asset:          "asset",
+	BatteryReading: "battery_reading",
+	Config:         "config",
+	Outbox:         "outbox",
+	Reading:        "reading",
+	Usage:          "usage",
 }
@@ -25,72 +25,142 @@ import (
 
 // Config is an object representing the database table.
 type Config struct {
-	AppID           int64             `boil:"app_id" json:"app_id" toml:"app_id" yaml:"app_id"`
-	Config          types.JSON        `boil:"config" json:"config" toml:"config" yaml:"config"`
-	Enable          null.Bool         `boil:"enable" json:"enable,omitempty" toml:"enable" yaml:"enable,omitempty"`
-	Description     null.String       `boil:"description" json:"description,omitempty" toml:"description" yaml:"description,omitempty"`
-	AssetID         null.Int32        `boil:"asset_id" json:"asset_id,omitempty" toml:"asset_id" yaml:"asset_id,omitempty"`
-	IntervalSec     int32             `boil:"interval_sec" json:"interval_sec" toml:"interval_sec" yaml:"interval_sec"`
-	AuthTimeout     int32             `boil:"auth_timeout" json:"auth_timeout" toml:"auth_timeout" yaml:"auth_timeout"`
-	RequestTimeout  int32             `boil:"request_timeout" json:"request_timeout" toml:"request_timeout" yaml:"request_timeout"`
-	InactiveTimeout null.Int32        `boil:"inactive_timeout" json:"inactive_timeout,omitempty" toml:"inactive_timeout" yaml:"inactive_timeout,omitempty"`
-	Active          null.Bool         `boil:"active" json:"active,omitempty" toml:"active" yaml:"active,omitempty"`
-	ProjIds         types.StringArray `boil:"proj_ids" json:"proj_ids,omitempty" toml:"proj_ids" yaml:"proj_ids,omitempty"`
+	AppID                     int64             `boil:"app_id" json:"app_id" toml:"app_id" yaml:"app_id"`
+	Config                    types.JSON        `boil:"config" json:"config" toml:"config" yaml:"config"`
+	Enable                    null.Bool         `boil:"enable" json:"enable,omitempty" toml:"enable" yaml:"enable,omitempty"`
+	Description               null.String       `boil:"description" json:"description,omitempty" toml:"description" yaml:"description,omitempty"`
+	AssetID                   null.Int32        `boil:"asset_id" json:"asset_id,omitempty" toml:"asset_id" yaml:"asset_id,omitempty"`
+	IntervalSec               int32             `boil:"interval_sec" json:"interval_sec" toml:"interval_sec" yaml:"interval_sec"`
+	AuthTimeout               int32             `boil:"auth_timeout" json:"auth_timeout" toml:"auth_timeout" yaml:"auth_timeout"`
+	RequestTimeout            int32             `boil:"request_timeout" json:"request_timeout" toml:"request_timeout" yaml:"request_timeout"`
+	InactiveTimeout           null.Int32        `boil:"inactive_timeout" json:"inactive_timeout,omitempty" toml:"inactive_timeout" yaml:"inactive_timeout,omitempty"`
+	Active                    null.Bool         `boil:"active" json:"active,omitempty" toml:"active" yaml:"active,omitempty"`
+	ProjIds                   types.StringArray `boil:"proj_ids" json:"proj_ids,omitempty" toml:"proj_ids" yaml:"proj_ids,omitempty"`
+	RefreshIntervalSec        null.Int32        `boil:"refresh_interval_sec" json:"refresh_interval_sec,omitempty" toml:"refresh_interval_sec" yaml:"refresh_interval_sec,omitempty"`
+	Concurrency               null.Int32        `boil:"concurrency" json:"concurrency,omitempty" toml:"concurrency" yaml:"concurrency,omitempty"`
+	RequestsPerSec            null.Int32        `boil:"requests_per_sec" json:"requests_per_sec,omitempty" toml:"requests_per_sec" yaml:"requests_per_sec,omitempty"`
+	MaxRetries                null.Int32        `boil:"max_retries" json:"max_retries,omitempty" toml:"max_retries" yaml:"max_retries,omitempty"`
+	RetryDelaySec             null.Int32        `boil:"retry_delay_sec" json:"retry_delay_sec,omitempty" toml:"retry_delay_sec" yaml:"retry_delay_sec,omitempty"`
+	BreakerThreshold          null.Int32        `boil:"breaker_threshold" json:"breaker_threshold,omitempty" toml:"breaker_threshold" yaml:"breaker_threshold,omitempty"`
+	BreakerProbeSec           null.Int32        `boil:"breaker_probe_sec" json:"breaker_probe_sec,omitempty" toml:"breaker_probe_sec" yaml:"breaker_probe_sec,omitempty"`
+	CircuitState              null.String       `boil:"circuit_state" json:"circuit_state,omitempty" toml:"circuit_state" yaml:"circuit_state,omitempty"`
+	AttributeMappings         null.JSON         `boil:"attribute_mappings" json:"attribute_mappings,omitempty" toml:"attribute_mappings" yaml:"attribute_mappings,omitempty"`
+	ContentCategoryAssetTypes null.Bool         `boil:"content_category_asset_types" json:"content_category_asset_types,omitempty" toml:"content_category_asset_types" yaml:"content_category_asset_types,omitempty"`
+	AdaptivePolling           null.Bool         `boil:"adaptive_polling" json:"adaptive_polling,omitempty" toml:"adaptive_polling" yaml:"adaptive_polling,omitempty"`
+	MinIntervalSec            null.Int32        `boil:"min_interval_sec" json:"min_interval_sec,omitempty" toml:"min_interval_sec" yaml:"min_interval_sec,omitempty"`
+	MaxIntervalSec            null.Int32        `boil:"max_interval_sec" json:"max_interval_sec,omitempty" toml:"max_interval_sec" yaml:"max_interval_sec,omitempty"`
+	UrgentFillLevel           null.Int32        `boil:"urgent_fill_level" json:"urgent_fill_level,omitempty" toml:"urgent_fill_level" yaml:"urgent_fill_level,omitempty"`
 
 	R *configR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L configL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ConfigColumns = struct {
-	AppID           string
-	Config          string
-	Enable          string
-	Description     string
-	AssetID         string
-	IntervalSec     string
-	AuthTimeout     string
-	RequestTimeout  string
-	InactiveTimeout string
-	Active          string
-	ProjIds         string
+	AppID                     string
+	Config                    string
+	Enable                    string
+	Description               string
+	AssetID                   string
+	IntervalSec               string
+	AuthTimeout               string
+	RequestTimeout            string
+	InactiveTimeout           string
+	Active                    string
+	ProjIds                   string
+	RefreshIntervalSec        string
+	Concurrency               string
+	RequestsPerSec            string
+	MaxRetries                string
+	RetryDelaySec             string
+	BreakerThreshold          string
+	BreakerProbeSec           string
+	CircuitState              string
+	AttributeMappings         string
+	ContentCategoryAssetTypes string
+	AdaptivePolling           string
+	MinIntervalSec            string
+	MaxIntervalSec            string
+	UrgentFillLevel           string
 }{
-	AppID:           "app_id",
-	Config:          "config",
-	Enable:          "enable",
-	Description:     "description",
-	AssetID:         "asset_id",
-	IntervalSec:     "interval_sec",
-	AuthTimeout:     "auth_timeout",
-	RequestTimeout:  "request_timeout",
-	InactiveTimeout: "inactive_timeout",
-	Active:          "active",
-	ProjIds:         "proj_ids",
+	AppID:                     "app_id",
+	Config:                    "config",
+	Enable:                    "enable",
+	Description:               "description",
+	AssetID:                   "asset_id",
+	IntervalSec:               "interval_sec",
+	AuthTimeout:               "auth_timeout",
+	RequestTimeout:            "request_timeout",
+	InactiveTimeout:           "inactive_timeout",
+	Active:                    "active",
+	ProjIds:                   "proj_ids",
+	RefreshIntervalSec:        "refresh_interval_sec",
+	Concurrency:               "concurrency",
+	RequestsPerSec:            "requests_per_sec",
+	MaxRetries:                "max_retries",
+	RetryDelaySec:             "retry_delay_sec",
+	BreakerThreshold:          "breaker_threshold",
+	BreakerProbeSec:           "breaker_probe_sec",
+	CircuitState:              "circuit_state",
+	AttributeMappings:         "attribute_mappings",
+	ContentCategoryAssetTypes: "content_category_asset_types",
+	AdaptivePolling:           "adaptive_polling",
+	MinIntervalSec:            "min_interval_sec",
+	MaxIntervalSec:            "max_interval_sec",
+	UrgentFillLevel:           "urgent_fill_level",
 }
 
 var ConfigTableColumns = struct {
-	AppID           string
-	Config          string
-	Enable          string
-	Description     string
-	AssetID         string
-	IntervalSec     string
-	AuthTimeout     string
-	RequestTimeout  string
-	InactiveTimeout string
-	Active          string
-	ProjIds         string
+	AppID                     string
+	Config                    string
+	Enable                    string
+	Description               string
+	AssetID                   string
+	IntervalSec               string
+	AuthTimeout               string
+	RequestTimeout            string
+	InactiveTimeout           string
+	Active                    string
+	ProjIds                   string
+	RefreshIntervalSec        string
+	Concurrency               string
+	RequestsPerSec            string
+	MaxRetries                string
+	RetryDelaySec             string
+	BreakerThreshold          string
+	BreakerProbeSec           string
+	CircuitState              string
+	AttributeMappings         string
+	ContentCategoryAssetTypes string
+	AdaptivePolling           string
+	MinIntervalSec            string
+	MaxIntervalSec            string
+	UrgentFillLevel           string
 }{
-	AppID:           "config.app_id",
-	Config:          "config.config",
-	Enable:          "config.enable",
-	Description:     "config.description",
-	AssetID:         "config.asset_id",
-	IntervalSec:     "config.interval_sec",
-	AuthTimeout:     "config.auth_timeout",
-	RequestTimeout:  "config.request_timeout",
-	InactiveTimeout: "config.inactive_timeout",
-	Active:          "config.active",
-	ProjIds:         "config.proj_ids",
+	AppID:                     "config.app_id",
+	Config:                    "config.config",
+	Enable:                    "config.enable",
+	Description:               "config.description",
+	AssetID:                   "config.asset_id",
+	IntervalSec:               "config.interval_sec",
+	AuthTimeout:               "config.auth_timeout",
+	RequestTimeout:            "config.request_timeout",
+	InactiveTimeout:           "config.inactive_timeout",
+	Active:                    "config.active",
+	ProjIds:                   "config.proj_ids",
+	RefreshIntervalSec:        "config.refresh_interval_sec",
+	Concurrency:               "config.concurrency",
+	RequestsPerSec:            "config.requests_per_sec",
+	MaxRetries:                "config.max_retries",
+	RetryDelaySec:             "config.retry_delay_sec",
+	BreakerThreshold:          "config.breaker_threshold",
+	BreakerProbeSec:           "config.breaker_probe_sec",
+	CircuitState:              "config.circuit_state",
+	AttributeMappings:         "config.attribute_mappings",
+	ContentCategoryAssetTypes: "config.content_category_asset_types",
+	AdaptivePolling:           "config.adaptive_polling",
+	MinIntervalSec:            "config.min_interval_sec",
+	MaxIntervalSec:            "config.max_interval_sec",
+	UrgentFillLevel:           "config.urgent_fill_level",
 }
 
 // Generated where
@@ -140,44 +210,6 @@ func (w whereHelpernull_Bool) GTE(x null.Bool) qm.QueryMod {
 func (w whereHelpernull_Bool) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpernull_Bool) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
-type whereHelpernull_String struct{ field string }
-
-func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
 type whereHelpernull_Int32 struct{ field string }
 
 func (w whereHelpernull_Int32) EQ(x null.Int32) qm.QueryMod {
@@ -242,30 +274,82 @@ func (w whereHelpertypes_StringArray) IsNotNull() qm.QueryMod {
 	return qmhelper.WhereIsNotNull(w.field)
 }
 
+type whereHelpernull_JSON struct{ field string }
+
+func (w whereHelpernull_JSON) EQ(x null.JSON) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_JSON) NEQ(x null.JSON) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_JSON) LT(x null.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_JSON) LTE(x null.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_JSON) GT(x null.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_JSON) GTE(x null.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_JSON) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_JSON) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var ConfigWhere = struct {
-	AppID           whereHelperint64
-	Config          whereHelpertypes_JSON
-	Enable          whereHelpernull_Bool
-	Description     whereHelpernull_String
-	AssetID         whereHelpernull_Int32
-	IntervalSec     whereHelperint32
-	AuthTimeout     whereHelperint32
-	RequestTimeout  whereHelperint32
-	InactiveTimeout whereHelpernull_Int32
-	Active          whereHelpernull_Bool
-	ProjIds         whereHelpertypes_StringArray
+	AppID                     whereHelperint64
+	Config                    whereHelpertypes_JSON
+	Enable                    whereHelpernull_Bool
+	Description               whereHelpernull_String
+	AssetID                   whereHelpernull_Int32
+	IntervalSec               whereHelperint32
+	AuthTimeout               whereHelperint32
+	RequestTimeout            whereHelperint32
+	InactiveTimeout           whereHelpernull_Int32
+	Active                    whereHelpernull_Bool
+	ProjIds                   whereHelpertypes_StringArray
+	RefreshIntervalSec        whereHelpernull_Int32
+	Concurrency               whereHelpernull_Int32
+	RequestsPerSec            whereHelpernull_Int32
+	MaxRetries                whereHelpernull_Int32
+	RetryDelaySec             whereHelpernull_Int32
+	BreakerThreshold          whereHelpernull_Int32
+	BreakerProbeSec           whereHelpernull_Int32
+	CircuitState              whereHelpernull_String
+	AttributeMappings         whereHelpernull_JSON
+	ContentCategoryAssetTypes whereHelpernull_Bool
+	AdaptivePolling           whereHelpernull_Bool
+	MinIntervalSec            whereHelpernull_Int32
+	MaxIntervalSec            whereHelpernull_Int32
+	UrgentFillLevel           whereHelpernull_Int32
 }{
-	AppID:           whereHelperint64{field: "\"hailo\".\"config\".\"app_id\""},
-	Config:          whereHelpertypes_JSON{field: "\"hailo\".\"config\".\"config\""},
-	Enable:          whereHelpernull_Bool{field: "\"hailo\".\"config\".\"enable\""},
-	Description:     whereHelpernull_String{field: "\"hailo\".\"config\".\"description\""},
-	AssetID:         whereHelpernull_Int32{field: "\"hailo\".\"config\".\"asset_id\""},
-	IntervalSec:     whereHelperint32{field: "\"hailo\".\"config\".\"interval_sec\""},
-	AuthTimeout:     whereHelperint32{field: "\"hailo\".\"config\".\"auth_timeout\""},
-	RequestTimeout:  whereHelperint32{field: "\"hailo\".\"config\".\"request_timeout\""},
-	InactiveTimeout: whereHelpernull_Int32{field: "\"hailo\".\"config\".\"inactive_timeout\""},
-	Active:          whereHelpernull_Bool{field: "\"hailo\".\"config\".\"active\""},
-	ProjIds:         whereHelpertypes_StringArray{field: "\"hailo\".\"config\".\"proj_ids\""},
+	AppID:                     whereHelperint64{field: "\"hailo\".\"config\".\"app_id\""},
+	Config:                    whereHelpertypes_JSON{field: "\"hailo\".\"config\".\"config\""},
+	Enable:                    whereHelpernull_Bool{field: "\"hailo\".\"config\".\"enable\""},
+	Description:               whereHelpernull_String{field: "\"hailo\".\"config\".\"description\""},
+	AssetID:                   whereHelpernull_Int32{field: "\"hailo\".\"config\".\"asset_id\""},
+	IntervalSec:               whereHelperint32{field: "\"hailo\".\"config\".\"interval_sec\""},
+	AuthTimeout:               whereHelperint32{field: "\"hailo\".\"config\".\"auth_timeout\""},
+	RequestTimeout:            whereHelperint32{field: "\"hailo\".\"config\".\"request_timeout\""},
+	InactiveTimeout:           whereHelpernull_Int32{field: "\"hailo\".\"config\".\"inactive_timeout\""},
+	Active:                    whereHelpernull_Bool{field: "\"hailo\".\"config\".\"active\""},
+	ProjIds:                   whereHelpertypes_StringArray{field: "\"hailo\".\"config\".\"proj_ids\""},
+	RefreshIntervalSec:        whereHelpernull_Int32{field: "\"hailo\".\"config\".\"refresh_interval_sec\""},
+	Concurrency:               whereHelpernull_Int32{field: "\"hailo\".\"config\".\"concurrency\""},
+	RequestsPerSec:            whereHelpernull_Int32{field: "\"hailo\".\"config\".\"requests_per_sec\""},
+	MaxRetries:                whereHelpernull_Int32{field: "\"hailo\".\"config\".\"max_retries\""},
+	RetryDelaySec:             whereHelpernull_Int32{field: "\"hailo\".\"config\".\"retry_delay_sec\""},
+	BreakerThreshold:          whereHelpernull_Int32{field: "\"hailo\".\"config\".\"breaker_threshold\""},
+	BreakerProbeSec:           whereHelpernull_Int32{field: "\"hailo\".\"config\".\"breaker_probe_sec\""},
+	CircuitState:              whereHelpernull_String{field: "\"hailo\".\"config\".\"circuit_state\""},
+	AttributeMappings:         whereHelpernull_JSON{field: "\"hailo\".\"config\".\"attribute_mappings\""},
+	ContentCategoryAssetTypes: whereHelpernull_Bool{field: "\"hailo\".\"config\".\"content_category_asset_types\""},
+	AdaptivePolling:           whereHelpernull_Bool{field: "\"hailo\".\"config\".\"adaptive_polling\""},
+	MinIntervalSec:            whereHelpernull_Int32{field: "\"hailo\".\"config\".\"min_interval_sec\""},
+	MaxIntervalSec:            whereHelpernull_Int32{field: "\"hailo\".\"config\".\"max_interval_sec\""},
+	UrgentFillLevel:           whereHelpernull_Int32{field: "\"hailo\".\"config\".\"urgent_fill_level\""},
 }
 
 // ConfigRels is where relationship names are stored.
@@ -285,9 +369,9 @@ func (*configR) NewStruct() *configR {
 type configL struct{}
 
 var (
-	configAllColumns            = []string{"app_id", "config", "enable", "description", "asset_id", "interval_sec", "auth_timeout", "request_timeout", "inactive_timeout", "active", "proj_ids"}
+	configAllColumns            = []string{"app_id", "config", "enable", "description", "asset_id", "interval_sec", "auth_timeout", "request_timeout", "inactive_timeout", "active", "proj_ids", "refresh_interval_sec", "concurrency", "requests_per_sec", "max_retries", "retry_delay_sec", "breaker_threshold", "breaker_probe_sec", "circuit_state", "attribute_mappings", "content_category_asset_types", "adaptive_polling", "min_interval_sec", "max_interval_sec", "urgent_fill_level"}
 	configColumnsWithoutDefault = []string{"config", "interval_sec"}
-	configColumnsWithDefault    = []string{"app_id", "enable", "description", "asset_id", "auth_timeout", "request_timeout", "inactive_timeout", "active", "proj_ids"}
+	configColumnsWithDefault    = []string{"app_id", "enable", "description", "asset_id", "auth_timeout", "request_timeout", "inactive_timeout", "active", "proj_ids", "refresh_interval_sec", "concurrency", "requests_per_sec", "max_retries", "retry_delay_sec", "breaker_threshold", "breaker_probe_sec", "circuit_state", "attribute_mappings", "content_category_asset_types", "adaptive_polling", "min_interval_sec", "max_interval_sec", "urgent_fill_level"}
 	configPrimaryKeyColumns     = []string{"app_id"}
 	configGeneratedColumns      = []string{}
 )
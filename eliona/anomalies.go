@@ -0,0 +1,58 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"context"
+	"hailo/analytics"
+	"hailo/apiserver"
+	"hailo/conf"
+	"sync"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// anomalyHistoryDays is the number of days of readings evaluated by the anomaly rules on each run
+const anomalyHistoryDays = 30
+
+// faultHoldTime is how long the sensor fault of a device is set after an anomaly ended
+const faultHoldTime = 24 * time.Hour
+
+// binAnomalies holds the anomalies last detected in the history of each bin
+var binAnomalies sync.Map
+
+// sensorFault returns true, if an anomaly of the bin lasted until the hold time before now. The anomaly rules are
+// evaluated on the history of the bin only if a new reading was stored, otherwise the anomalies last detected are
+// used. If the history cannot be read, nil is returned.
+func sensorFault(config apiserver.Configuration, deviceId string, newReading bool) *bool {
+	key := registeredDeviceKey{common.Val(config.Id), deviceId}
+	if value, found := binAnomalies.Load(key); found && !newReading {
+		return common.Ptr(analytics.SensorFault(value.([]apiserver.SensorAnomaly), time.Now().Add(-faultHoldTime)))
+	}
+	readings, err := conf.GetDeviceReadings(context.Background(), common.Val(config.Id), deviceId, time.Now().AddDate(0, 0, -anomalyHistoryDays))
+	if err != nil {
+		log.Error("Hailo", "Could not read history of bin %s: %v", deviceId, err)
+		return nil
+	}
+	anomalies := analytics.SensorAnomalies(readings)
+	for _, anomaly := range anomalies {
+		log.Debug("Hailo", "Sensor anomaly %s of bin %s: %s", anomaly.Rule, deviceId, anomaly.Description)
+	}
+	binAnomalies.Store(key, anomalies)
+	return common.Ptr(analytics.SensorFault(anomalies, time.Now().Add(-faultHoldTime)))
+}
@@ -20,11 +20,13 @@ import (
 	"fmt"
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-eliona/client"
 	"github.com/eliona-smart-building-assistant/go-utils/common"
 	"github.com/eliona-smart-building-assistant/go-utils/log"
 	"hailo/apiserver"
 	"hailo/conf"
 	"hailo/hailo"
+	"sync"
 )
 
 const (
@@ -33,17 +35,27 @@ const (
 	DigitalHubAssetType       = "Hailo Digital Hub"
 )
 
+// assetCreationMutex prevents creating the same asset twice if devices are processed in parallel
+var assetCreationMutex sync.Mutex
+
 // CreateAssetsIfNecessary create all assets for specification including sub specification if not already exists
 func CreateAssetsIfNecessary(config apiserver.Configuration, spec hailo.Spec) error {
 
+	position := deviceLocation(config, spec, nil)
+	registerDevice(config, spec, "", position)
 	for _, projectId := range conf.ProjIds(config) {
-		assetId, err := createAssetIfNecessary(config, projectId, nil, spec)
+		assetId, err := createAssetIfNecessary(config, projectId, nil, spec, position)
 		if err != nil {
 			log.Error("Hailo", "Could not create assets for device %s: %v", spec.DeviceId, err)
 			return err
 		}
 		for _, subSpec := range spec.DeviceTypeSpecific.ComponentIdList {
-			_, err = createAssetIfNecessary(config, projectId, assetId, subSpec)
+			if SupportedDeviceKind(subSpec) == nil {
+				continue
+			}
+			subPosition := deviceLocation(config, subSpec, position)
+			registerDevice(config, subSpec, spec.DeviceId, subPosition)
+			_, err = createAssetIfNecessary(config, projectId, assetId, subSpec, subPosition)
 			if err != nil {
 				log.Error("Hailo", "Could not create assets for sub device %s: %v", subSpec.DeviceId, err)
 				return err
@@ -54,8 +66,9 @@ func CreateAssetsIfNecessary(config apiserver.Configuration, spec hailo.Spec) er
 	return nil
 }
 
-// createAssetIfNecessary create asset for specification if not already exists
-func createAssetIfNecessary(config apiserver.Configuration, projectId string, parentAssetId *int32, spec hailo.Spec) (*int32, error) {
+// createAssetIfNecessary create asset for specification if not already exists. A new asset is placed at the given
+// location.
+func createAssetIfNecessary(config apiserver.Configuration, projectId string, parentAssetId *int32, spec hailo.Spec, position *hailo.Position) (*int32, error) {
 
 	// Get known asset id from configuration
 	existingId, err := conf.GetAssetId(context.Background(), config, projectId, spec.DeviceId)
@@ -63,42 +76,83 @@ func createAssetIfNecessary(config apiserver.Configuration, projectId string, pa
 		return existingId, nil
 	}
 
+	// Check again while holding the lock, because a parallel worker could have created the asset in the meantime
+	assetCreationMutex.Lock()
+	defer assetCreationMutex.Unlock()
+	existingId, err = conf.GetAssetId(context.Background(), config, projectId, spec.DeviceId)
+	if err != nil {
+		return nil, err
+	}
+	if existingId != nil {
+		return existingId, nil
+	}
+
 	log.Debug("hailo", "Creating new asset for project %s and spec %s.", projectId, spec.DeviceId)
 
 	// If no asset id exists for project and configuration, create a new one
 	name := name(spec)
 	description := description(spec)
 
-	newId, err := asset.UpsertAsset(api.Asset{
+	newAsset := api.Asset{
 		ProjectId:               projectId,
 		GlobalAssetIdentifier:   spec.Generic.DeviceSerial,
 		Name:                    *api.NewNullableString(common.Ptr(name)),
-		AssetType:               assetType(spec),
+		AssetType:               creationAssetType(config, spec),
 		Description:             *api.NewNullableString(common.Ptr(description)),
 		ParentLocationalAssetId: *api.NewNullableInt32(parentAssetId),
-	})
+		Tags:                    assetTags(spec),
+	}
+	located := position != nil && position.Latitude != nil && position.Longitude != nil
+	if located {
+		newAsset.Latitude = *api.NewNullableFloat64(position.Latitude)
+		newAsset.Longitude = *api.NewNullableFloat64(position.Longitude)
+	}
+	newId, err := asset.UpsertAsset(newAsset)
 	if err != nil {
 		return nil, err
 	}
 	if newId == nil {
 		return nil, fmt.Errorf("cannot create asset: %s", name)
 	}
+	if located {
+		assetLocations.Store(*newId, *position)
+	}
 
 	// Remember the asset id for further usage
 	err = conf.InsertAsset(context.Background(), config, projectId, spec.DeviceId, *newId)
 	if err != nil {
 		return newId, err
 	}
+	err = rememberTags(*newId, newAsset.Tags)
+	if err != nil {
+		return newId, err
+	}
 
 	return newId, nil
 }
 
-// assetType from Hailo FDS specification
+// updateAsset reads an existing asset from Eliona, applies the modification and writes the asset back
+func updateAsset(assetId int32, modify func(apiAsset *api.Asset)) error {
+	apiAsset, _, err := client.NewClient().AssetsAPI.
+		GetAssetById(client.AuthenticationContext(), assetId).
+		Execute()
+	if err != nil {
+		return err
+	}
+	modify(apiAsset)
+	_, _, err = client.NewClient().AssetsAPI.
+		PutAssetById(client.AuthenticationContext(), assetId).
+		Asset(*apiAsset).
+		Execute()
+	return err
+}
+
+// assetType from the kind of device defined in the Hailo FDS specification
 func assetType(specification hailo.Spec) string {
-	if specification.DeviceTypeSpecific.ComponentIdList != nil {
-		return RecyclingStationAssetType
+	if kind := DeviceKindOf(specification); kind != nil {
+		return kind.AssetType
 	}
-	return BinAssetType
+	return ""
 }
 
 func name(specification hailo.Spec) string {
@@ -0,0 +1,225 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"hailo/apiserver"
+	"os"
+	"path"
+	"sync"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+// Sources of values which can be mapped to attributes
+const (
+	sourceSpec   = "spec"
+	sourceStatus = "status"
+	sourceDiag   = "diag"
+	sourceApp    = "app"
+)
+
+// Conversions of timestamps to the time between the timestamp and now
+const (
+	conversionHours = "hours"
+	conversionDays  = "days"
+)
+
+// mappingSources holds the documents read from FDS and the values computed by the app for a device by source
+type mappingSources map[string]map[string]interface{}
+
+// defaultAttributeMappingFile defines the mapping of FDS values to attributes used if not overridden by the
+// configuration
+//
+//go:embed attribute-mapping.json
+var defaultAttributeMappingFile []byte
+
+// defaultAttributeMappings are the mappings of the app merged with the mappings of the file defined by the
+// environment variable ATTRIBUTE_MAPPING_FILE. They are loaded by LoadAttributeMappings at the start of the app.
+var defaultAttributeMappings []apiserver.AttributeMapping
+
+// LoadAttributeMappings reads the mappings of the app and merges the mappings of the file defined by the environment
+// variable ATTRIBUTE_MAPPING_FILE, so that new FDS values can be mapped without a new release
+func LoadAttributeMappings() error {
+	mappings, err := readDefaultAttributeMappings(common.Getenv("ATTRIBUTE_MAPPING_FILE", ""))
+	if err != nil {
+		return err
+	}
+	defaultAttributeMappings = mappings
+	return nil
+}
+
+// readDefaultAttributeMappings reads the mappings of the app merged with the mappings of the named file. Without
+// name only the mappings of the app are returned.
+func readDefaultAttributeMappings(name string) ([]apiserver.AttributeMapping, error) {
+	var attributeMappings []apiserver.AttributeMapping
+	err := json.Unmarshal(defaultAttributeMappingFile, &attributeMappings)
+	if err != nil {
+		return nil, fmt.Errorf("default attribute mapping: %v", err)
+	}
+	if name == "" {
+		return attributeMappings, nil
+	}
+	overrides, err := readAttributeMappingFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("attribute mapping file %s: %v", name, err)
+	}
+	return mergeAttributeMappings(attributeMappings, overrides, func(attributeMapping apiserver.AttributeMapping, err error) {
+		log.Error("Hailo", "Ignore invalid attribute mapping in %s for attribute '%s': %v", name, attributeMapping.Attribute, err)
+	}), nil
+}
+
+// readAttributeMappingFile reads attribute mappings from a JSON or YAML file
+func readAttributeMappingFile(name string) ([]apiserver.AttributeMapping, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	switch path.Ext(name) {
+	case ".json":
+	case ".yaml", ".yml":
+		// the mapping model only defines JSON names, so YAML is converted to JSON first
+		var document interface{}
+		err = yaml.Unmarshal(content, &document)
+		if err != nil {
+			return nil, err
+		}
+		content, err = json.Marshal(document)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown file type")
+	}
+	var attributeMappings []apiserver.AttributeMapping
+	err = json.Unmarshal(content, &attributeMappings)
+	return attributeMappings, err
+}
+
+// configAttributeMappings caches the attribute mappings merged for each configuration until the configuration changes
+var configAttributeMappings sync.Map
+
+// attributeMappings returns the default attribute mappings merged with the mappings of the configuration. A mapping
+// of the configuration replaces the default mapping for the same asset type and attribute or removes it, if the
+// mapping is disabled. Invalid mappings of the configuration are logged and ignored. The mappings are merged once for
+// each configuration and cached until ForgetAttributeMappings is called for the configuration.
+func attributeMappings(config apiserver.Configuration) []apiserver.AttributeMapping {
+	if config.AttributeMappings == nil || len(*config.AttributeMappings) == 0 {
+		return defaultAttributeMappings
+	}
+	if cached, ok := configAttributeMappings.Load(common.Val(config.Id)); ok {
+		return cached.([]apiserver.AttributeMapping)
+	}
+
+	mappings := mergeAttributeMappings(defaultAttributeMappings, *config.AttributeMappings, func(attributeMapping apiserver.AttributeMapping, err error) {
+		log.Error("Hailo", "Ignore invalid attribute mapping for config %d and attribute '%s': %v", common.Val(config.Id), attributeMapping.Attribute, err)
+	})
+	err := upsertDeclaredAttributes(mappings)
+	if err != nil {
+		log.Error("Hailo", "Could not add attributes mapped for config %d to asset types: %v", common.Val(config.Id), err)
+	}
+	configAttributeMappings.Store(common.Val(config.Id), mappings)
+	return mappings
+}
+
+// ForgetAttributeMappings removes the attribute mappings cached for the configuration, so that changed mappings of the
+// configuration are merged again
+func ForgetAttributeMappings(configId int64) {
+	configAttributeMappings.Delete(configId)
+}
+
+// mergeAttributeMappings replaces or removes default mappings by the overrides. Invalid overrides are passed to the
+// invalid function and ignored.
+func mergeAttributeMappings(defaults []apiserver.AttributeMapping, overrides []apiserver.AttributeMapping, invalid func(apiserver.AttributeMapping, error)) []apiserver.AttributeMapping {
+	type key struct{ assetType, attribute string }
+	overridden := make(map[key]apiserver.AttributeMapping)
+	var added []apiserver.AttributeMapping
+	for _, override := range overrides {
+		if !common.Val(override.Disable) {
+			if err := validateAttributeMapping(override); err != nil {
+				invalid(override, err)
+				continue
+			}
+		}
+		k := key{override.AssetType, override.Attribute}
+		if _, ok := overridden[k]; !ok {
+			added = append(added, override)
+		}
+		overridden[k] = override
+	}
+
+	var mappings []apiserver.AttributeMapping
+	for _, attributeMapping := range defaults {
+		k := key{attributeMapping.AssetType, attributeMapping.Attribute}
+		if override, ok := overridden[k]; ok {
+			if !common.Val(override.Disable) {
+				mappings = append(mappings, override)
+			}
+			delete(overridden, k)
+			continue
+		}
+		mappings = append(mappings, attributeMapping)
+	}
+	for _, attributeMapping := range added {
+		k := key{attributeMapping.AssetType, attributeMapping.Attribute}
+		if override, ok := overridden[k]; ok && !common.Val(override.Disable) {
+			mappings = append(mappings, override)
+		}
+	}
+	return mappings
+}
+
+// upsertDeclaredAttributes adds the attributes declared with an attribute type by the mappings to the asset types.
+// Attributes of bins are added to the asset types of all content categories too.
+func upsertDeclaredAttributes(mappings []apiserver.AttributeMapping) error {
+	for _, attributeMapping := range mappings {
+		if attributeMapping.AttributeType == nil {
+			continue
+		}
+		assetTypes := []string{attributeMapping.AssetType}
+		if attributeMapping.AssetType == BinAssetType {
+			assetTypes = binAssetTypes
+		}
+		for _, assetType := range assetTypes {
+			attribute := api.AssetTypeAttribute{
+				AssetTypeName: *api.NewNullableString(common.Ptr(assetType)),
+				Name:          attributeMapping.Attribute,
+				Subtype:       api.DataSubtype(attributeMapping.Subtype),
+				Type:          *api.NewNullableString(attributeMapping.AttributeType),
+				Enable:        common.Ptr(true),
+				Unit:          *api.NewNullableString(attributeMapping.Unit),
+			}
+			if attributeMapping.Precision != nil {
+				attribute.Precision = *api.NewNullableInt64(common.Ptr(int64(*attributeMapping.Precision)))
+			}
+			if attributeMapping.Label != nil {
+				attribute.Translation = *api.NewNullableTranslation(&api.Translation{De: attributeMapping.Label, En: attributeMapping.Label})
+			}
+			err := asset.UpsertAssetTypeAttribute(attribute)
+			if err != nil {
+				return fmt.Errorf("upserting attribute %s of asset type %s: %w", attributeMapping.Attribute, assetType, err)
+			}
+		}
+	}
+	return nil
+}
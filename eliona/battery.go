@@ -0,0 +1,238 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"hailo/analytics"
+	"hailo/apiserver"
+	"hailo/conf"
+	"hailo/hailo"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// DefaultReplacementDays is the planning period in days for battery replacements, if no period is requested
+const DefaultReplacementDays = 30
+
+// batteryForecastDays is the number of days of battery history used to forecast the depletion
+const batteryForecastDays = 90
+
+// batteryCutoff returns the battery level in percent at which a battery has to be replaced, defined by the
+// environment variable BATTERY_CUTOFF_LEVEL (default 10 %)
+func batteryCutoff() float64 {
+	cutoff, err := strconv.ParseFloat(common.Getenv("BATTERY_CUTOFF_LEVEL", "10"), 64)
+	if err != nil {
+		log.Warn("Hailo", "Invalid BATTERY_CUTOFF_LEVEL: %v", err)
+		cutoff = 10
+	}
+	return cutoff
+}
+
+// batteryForecast is the forecast of a device for the battery reading last stored
+type batteryForecast struct {
+	lastContact time.Time
+	depletion   *time.Time
+}
+
+// batteryForecasts holds the battery forecast for the reading last stored for each device
+var batteryForecasts sync.Map
+
+// forecastBattery stores the battery level mapped for the device in the history with the kind of the device (bin or
+// station) and forecasts the days until the battery falls to the cut-off level. The history is only stored and read,
+// if the last contact of the device changed since the last forecast, otherwise the last forecast is used. If the
+// device reports no battery level or the history is not sufficient, nil is returned.
+func forecastBattery(config apiserver.Configuration, kind string, assetType string, status hailo.Status, sources mappingSources) *float64 {
+	data := mapping{deviceId: status.DeviceId}.attributeData(attributeMappings(config), assetType, api.SUBTYPE_INPUT, sources)
+	level, ok := dataNumber(data["bat_level"])
+	if !ok || !status.Generic.LastContact.Valid {
+		return nil
+	}
+	key := registeredDeviceKey{common.Val(config.Id), status.DeviceId}
+	if value, found := batteryForecasts.Load(key); found && value.(batteryForecast).lastContact.Equal(status.Generic.LastContact.Time) {
+		return daysUntil(value.(batteryForecast).depletion, time.Now())
+	}
+	ctx := context.Background()
+	reading := conf.BatteryReading{
+		ConfigId:  common.Val(config.Id),
+		DeviceId:  status.DeviceId,
+		Kind:      kind,
+		Timestamp: status.Generic.LastContact.Time,
+		BatLevel:  level,
+	}
+	if device, ok := registeredDeviceOf(config, status.DeviceId); ok {
+		reading.StationId = device.parentId
+	}
+	err := conf.InsertBatteryReading(ctx, reading)
+	if err != nil {
+		log.Error("Hailo", "Could not store battery level for device %s: %v", status.DeviceId, err)
+		return nil
+	}
+	now := time.Now()
+	readings, err := conf.GetBatteryReadings(ctx, common.Val(config.Id), status.DeviceId, now.AddDate(0, 0, -batteryForecastDays))
+	if err != nil {
+		log.Error("Hailo", "Could not read battery history for device %s: %v", status.DeviceId, err)
+		return nil
+	}
+	daysLeft := analytics.BatteryDaysLeft(readings, batteryCutoff(), now)
+	forecast := batteryForecast{lastContact: status.Generic.LastContact.Time}
+	if daysLeft != nil {
+		forecast.depletion = common.Ptr(now.Add(time.Duration(*daysLeft * 24 * float64(time.Hour))))
+	}
+	batteryForecasts.Store(key, forecast)
+	return daysLeft
+}
+
+// daysUntil returns the days from now until the depletion rounded to one decimal, at least 0. Without depletion nil
+// is returned.
+func daysUntil(depletion *time.Time, now time.Time) *float64 {
+	if depletion == nil {
+		return nil
+	}
+	days := math.Max(0, math.Round(depletion.Sub(now).Hours()/24*10)/10)
+	return &days
+}
+
+// BatteryReplacements lists the devices of the configuration whose batteries fall to the cut-off level within the
+// days. The forecasts are calculated from the battery history stored in the database.
+func BatteryReplacements(config apiserver.Configuration, days int32) (apiserver.BatteryReplacementPlan, error) {
+	readings, err := conf.GetConfigBatteryReadings(context.Background(), common.Val(config.Id), time.Now().AddDate(0, 0, -batteryForecastDays))
+	if err != nil {
+		return apiserver.BatteryReplacementPlan{}, err
+	}
+	return batteryReplacementPlan(config, readings, days, time.Now()), nil
+}
+
+// batteryReplacementPlan lists the devices whose batteries fall to the cut-off level within the days from the
+// battery readings ordered by device and timestamp. The devices are grouped by the station or hub they belong to.
+// Stations and hubs group themselves.
+func batteryReplacementPlan(config apiserver.Configuration, readings []conf.BatteryReading, days int32, now time.Time) apiserver.BatteryReplacementPlan {
+	if days <= 0 {
+		days = DefaultReplacementDays
+	}
+	plan := apiserver.BatteryReplacementPlan{
+		ConfigId:    common.Val(config.Id),
+		Days:        days,
+		CutoffLevel: batteryCutoff(),
+		Groups:      []apiserver.BatteryReplacementGroup{},
+	}
+	var devices [][]conf.BatteryReading
+	kinds := make(map[string]string)
+	for i, reading := range readings {
+		if i == 0 || reading.DeviceId != readings[i-1].DeviceId {
+			devices = append(devices, nil)
+		}
+		devices[len(devices)-1] = append(devices[len(devices)-1], reading)
+		kinds[reading.DeviceId] = reading.Kind
+	}
+	groupIndex := make(map[string]int)
+	for _, deviceReadings := range devices {
+		replacement, ok := batteryReplacement(config, deviceReadings, days, now)
+		if !ok {
+			continue
+		}
+		last := deviceReadings[len(deviceReadings)-1]
+		groupId := last.StationId
+		if groupId == "" && last.Kind != binKind.Name {
+			groupId = last.DeviceId
+		}
+		index, found := groupIndex[groupId]
+		if !found {
+			index = len(plan.Groups)
+			groupIndex[groupId] = index
+			plan.Groups = append(plan.Groups, replacementGroup(config, groupId, kinds[groupId]))
+		}
+		plan.Groups[index].Devices = append(plan.Groups[index].Devices, replacement)
+	}
+	for _, group := range plan.Groups {
+		sort.SliceStable(group.Devices, func(i, j int) bool { return group.Devices[i].BatDaysLeft < group.Devices[j].BatDaysLeft })
+	}
+	sort.SliceStable(plan.Groups, func(i, j int) bool {
+		return plan.Groups[i].Devices[0].BatDaysLeft < plan.Groups[j].Devices[0].BatDaysLeft
+	})
+	return plan
+}
+
+// batteryReplacement returns the replacement of the battery of the device, if the battery falls to the cut-off level
+// within the days
+func batteryReplacement(config apiserver.Configuration, readings []conf.BatteryReading, days int32, now time.Time) (apiserver.BatteryReplacement, bool) {
+	daysLeft := analytics.BatteryDaysLeft(readings, batteryCutoff(), now)
+	if daysLeft == nil || *daysLeft > float64(days) {
+		return apiserver.BatteryReplacement{}, false
+	}
+	last := readings[len(readings)-1]
+	return apiserver.BatteryReplacement{
+		DeviceId:    last.DeviceId,
+		Kind:        last.Kind,
+		AssetId:     deviceAssetId(config, last.DeviceId),
+		BatLevel:    last.BatLevel,
+		BatDaysLeft: math.Floor(*daysLeft*10) / 10,
+		ReplaceBy:   now.Add(time.Duration(*daysLeft * 24 * float64(time.Hour))).Format("2006-01-02"),
+	}, true
+}
+
+// replacementGroup returns the group for the station or hub with the device id and kind. Devices without station or
+// hub are grouped with an empty device id. The kind is unknown for groups without battery readings.
+func replacementGroup(config apiserver.Configuration, deviceId string, kind string) apiserver.BatteryReplacementGroup {
+	group := apiserver.BatteryReplacementGroup{Devices: []apiserver.BatteryReplacement{}}
+	if deviceId == "" {
+		return group
+	}
+	group.DeviceId = common.Ptr(deviceId)
+	group.AssetId = deviceAssetId(config, deviceId)
+	if kind != "" {
+		group.Kind = common.Ptr(kind)
+	}
+	return group
+}
+
+// BatteryReplacementsCsv returns the devices of the plan as CSV with one line for each device
+func BatteryReplacementsCsv(plan apiserver.BatteryReplacementPlan) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	err := writer.Write([]string{"groupId", "groupKind", "deviceId", "kind", "assetId", "batLevel", "batDaysLeft", "replaceBy"})
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range plan.Groups {
+		for _, device := range group.Devices {
+			err = writer.Write([]string{
+				common.Val(group.DeviceId),
+				common.Val(group.Kind),
+				device.DeviceId,
+				device.Kind,
+				csvValue(device.AssetId),
+				csvValue(&device.BatLevel),
+				csvValue(&device.BatDaysLeft),
+				device.ReplaceBy,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
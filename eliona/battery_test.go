@@ -0,0 +1,74 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"hailo/apiserver"
+	"hailo/conf"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBatteryReplacements(t *testing.T) {
+	config := apiserver.Configuration{Id: common.Ptr[int64](4701)}
+	now := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	// days left until the default cut-off of 10 %: bin-1 5, bin-2 2.5, bin-3 2, bin-5 10 and station-1 60
+	readings := []conf.BatteryReading{
+		{DeviceId: "bin-1", Kind: "bin", StationId: "station-1", Timestamp: now.AddDate(0, 0, -10), BatLevel: 25},
+		{DeviceId: "bin-1", Kind: "bin", StationId: "station-1", Timestamp: now, BatLevel: 15},
+		{DeviceId: "bin-2", Kind: "bin", StationId: "station-1", Timestamp: now.AddDate(0, 0, -4), BatLevel: 23},
+		{DeviceId: "bin-2", Kind: "bin", StationId: "station-1", Timestamp: now, BatLevel: 15},
+		{DeviceId: "bin-3", Kind: "bin", Timestamp: now.AddDate(0, 0, -2), BatLevel: 12},
+		{DeviceId: "bin-3", Kind: "bin", Timestamp: now, BatLevel: 11},
+		{DeviceId: "bin-4", Kind: "bin", StationId: "station-1", Timestamp: now, BatLevel: 15},
+		{DeviceId: "bin-5", Kind: "bin", StationId: "hub-1", Timestamp: now.AddDate(0, 0, -2), BatLevel: 16},
+		{DeviceId: "bin-5", Kind: "bin", StationId: "hub-1", Timestamp: now, BatLevel: 15},
+		{DeviceId: "station-1", Kind: "station", Timestamp: now.AddDate(0, 0, -60), BatLevel: 20},
+		{DeviceId: "station-1", Kind: "station", Timestamp: now, BatLevel: 15},
+	}
+
+	plan := batteryReplacementPlan(config, readings, 0, now)
+	assert.Equal(t, int32(DefaultReplacementDays), plan.Days)
+	assert.Len(t, plan.Groups, 3)
+	assert.Nil(t, plan.Groups[0].DeviceId)
+	assert.Equal(t, "bin-3", plan.Groups[0].Devices[0].DeviceId)
+	assert.Equal(t, "station-1", *plan.Groups[1].DeviceId)
+	assert.Equal(t, "station", *plan.Groups[1].Kind)
+	assert.Len(t, plan.Groups[1].Devices, 2)
+	assert.Equal(t, "bin-2", plan.Groups[1].Devices[0].DeviceId)
+	assert.Equal(t, "hub-1", *plan.Groups[2].DeviceId)
+	assert.Nil(t, plan.Groups[2].Kind)
+
+	plan = batteryReplacementPlan(config, readings, 90, now)
+	assert.Len(t, plan.Groups[1].Devices, 3)
+	assert.Equal(t, "station", plan.Groups[1].Devices[2].Kind)
+
+	content, err := BatteryReplacementsCsv(plan)
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 6)
+	assert.Equal(t, ",,bin-3,bin,,11,2,2023-03-03", lines[1])
+}
+
+func TestDaysUntil(t *testing.T) {
+	now := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 2.5, *daysUntil(common.Ptr(now.Add(60*time.Hour)), now))
+	assert.Equal(t, 0.0, *daysUntil(common.Ptr(now.Add(-time.Hour)), now))
+	assert.Nil(t, daysUntil(nil, now))
+}
@@ -0,0 +1,93 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"hailo/apiserver"
+	"reflect"
+	"sync"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+)
+
+// writtenDataKey identifies data written for one asset and subtype
+type writtenDataKey struct {
+	assetId int32
+	subtype api.DataSubtype
+}
+
+// writtenData holds the data last written to Eliona for an asset and subtype
+type writtenData struct {
+	data        map[string]interface{}
+	lastContact time.Time
+	timestamp   time.Time
+	writtenAt   time.Time
+}
+
+// writtenDataCache holds the last written data for each asset and subtype
+var writtenDataCache sync.Map
+
+// isDataChanged checks if the data differs from the data last written for the asset and subtype. Data is also seen
+// as changed, if the last contact of the device has changed or the refresh interval of the configuration is exceeded.
+func isDataChanged(config apiserver.Configuration, assetId int32, subtype api.DataSubtype, lastContact time.Time, data map[string]interface{}) bool {
+	value, found := writtenDataCache.Load(writtenDataKey{assetId, subtype})
+	if !found {
+		return true
+	}
+	written := value.(writtenData)
+	if time.Since(written.writtenAt) >= time.Duration(config.RefreshIntervalSec)*time.Second {
+		return true
+	}
+	if !written.lastContact.Equal(lastContact) {
+		return true
+	}
+	return !reflect.DeepEqual(written.data, data)
+}
+
+// rememberWrittenData stores the data successfully written for the asset and subtype. If the last contact is
+// unknown, the last known timestamp is kept.
+func rememberWrittenData(assetId int32, subtype api.DataSubtype, lastContact time.Time, data map[string]interface{}) {
+	timestamp := lastContact
+	if timestamp.IsZero() {
+		timestamp = lastKnownTimestamp(assetId, subtype)
+	}
+	writtenDataCache.Store(writtenDataKey{assetId, subtype}, writtenData{
+		data:        data,
+		lastContact: lastContact,
+		timestamp:   timestamp,
+		writtenAt:   time.Now(),
+	})
+}
+
+// lastKnownTimestamp returns the last known timestamp of the data written for the asset and subtype or the zero
+// time, if no timestamp is known
+func lastKnownTimestamp(assetId int32, subtype api.DataSubtype) time.Time {
+	value, found := writtenDataCache.Load(writtenDataKey{assetId, subtype})
+	if !found {
+		return time.Time{}
+	}
+	return value.(writtenData).timestamp
+}
+
+// lastWrittenData returns the data last written for the asset and subtype or nil, if no data was written
+func lastWrittenData(assetId int32, subtype api.DataSubtype) map[string]interface{} {
+	value, found := writtenDataCache.Load(writtenDataKey{assetId, subtype})
+	if !found {
+		return nil
+	}
+	return value.(writtenData).data
+}
@@ -0,0 +1,48 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"github.com/stretchr/testify/assert"
+	"hailo/apiserver"
+	"testing"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+)
+
+func TestDataChanged(t *testing.T) {
+	config := apiserver.Configuration{RefreshIntervalSec: 3600}
+	lastContact := time.Now()
+	data := map[string]interface{}{"volumepercent": 42.0}
+
+	assert.True(t, isDataChanged(config, 4711, api.SUBTYPE_INPUT, lastContact, data))
+	rememberWrittenData(4711, api.SUBTYPE_INPUT, lastContact, data)
+	assert.False(t, isDataChanged(config, 4711, api.SUBTYPE_INPUT, lastContact, map[string]interface{}{"volumepercent": 42.0}))
+	assert.True(t, isDataChanged(config, 4711, api.SUBTYPE_INPUT, lastContact, map[string]interface{}{"volumepercent": 43.0}))
+	assert.True(t, isDataChanged(config, 4711, api.SUBTYPE_INPUT, lastContact.Add(time.Minute), data))
+	assert.True(t, isDataChanged(config, 4711, api.SUBTYPE_STATUS, lastContact, data))
+
+	config.RefreshIntervalSec = 0
+	assert.True(t, isDataChanged(config, 4711, api.SUBTYPE_INPUT, lastContact, data))
+
+	// unknown last contact keeps the last known timestamp and is unchanged in the next run
+	config.RefreshIntervalSec = 3600
+	rememberWrittenData(4711, api.SUBTYPE_INPUT, time.Time{}, data)
+	assert.Equal(t, lastContact, lastKnownTimestamp(4711, api.SUBTYPE_INPUT))
+	assert.False(t, isDataChanged(config, 4711, api.SUBTYPE_INPUT, time.Time{}, data))
+	assert.True(t, lastKnownTimestamp(4712, api.SUBTYPE_INPUT).IsZero())
+}
@@ -0,0 +1,191 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"context"
+	"hailo/apiserver"
+	"hailo/conf"
+	"hailo/hailo"
+	"strings"
+	"sync"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/db"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+const (
+	PaperBinAssetType    = "Hailo FDS Bin Paper"
+	PetBinAssetType      = "Hailo FDS Bin PET"
+	ResidualBinAssetType = "Hailo FDS Bin Residual"
+	OrganicBinAssetType  = "Hailo FDS Bin Organic"
+)
+
+// contentCategory defines the asset type for bins of one content category
+type contentCategory struct {
+	assetType   string
+	translation api.Translation
+}
+
+// contentCategories maps the normalized content category of the specification to the bin asset type
+var contentCategories = map[string]contentCategory{
+	"paper":    {PaperBinAssetType, api.Translation{De: common.Ptr("Hailo Papierbehälter"), En: common.Ptr("Hailo paper bin")}},
+	"pet":      {PetBinAssetType, api.Translation{De: common.Ptr("Hailo PET-Behälter"), En: common.Ptr("Hailo PET bin")}},
+	"residual": {ResidualBinAssetType, api.Translation{De: common.Ptr("Hailo Restmüllbehälter"), En: common.Ptr("Hailo residual waste bin")}},
+	"organic":  {OrganicBinAssetType, api.Translation{De: common.Ptr("Hailo Biomüllbehälter"), En: common.Ptr("Hailo organic waste bin")}},
+}
+
+// binAssetTypes lists the general bin asset type and all asset types specific for a content category
+var binAssetTypes = []string{BinAssetType, PaperBinAssetType, PetBinAssetType, ResidualBinAssetType, OrganicBinAssetType}
+
+// syncedTags caches the tags last set by the app for each asset id, which are stored in the asset mapping
+var syncedTags sync.Map
+
+// InitContentCategoryAssetTypes creates an asset type for each content category. The asset types are copies of the
+// bin asset type, so the same attributes are written.
+func InitContentCategoryAssetTypes(db.Connection) error {
+	for _, category := range contentCategories {
+		assetType, err := common.UnmarshalFile[api.AssetType]("eliona/asset-type-bin.json")
+		if err != nil {
+			return err
+		}
+		assetType.Name = category.assetType
+		assetType.Translation = *api.NewNullableTranslation(common.Ptr(category.translation))
+		err = asset.UpsertAssetType(assetType)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// creationAssetType returns the asset type for a new asset of the device. If enabled in the configuration, bins get
+// the asset type of their content category.
+func creationAssetType(config apiserver.Configuration, spec hailo.Spec) string {
+	assetType := assetType(spec)
+	if assetType != BinAssetType || config.ContentCategoryAssetTypes == nil || !*config.ContentCategoryAssetTypes {
+		return assetType
+	}
+	if category, ok := contentCategories[strings.ToLower(strings.TrimSpace(spec.DeviceTypeSpecific.ContentCategory))]; ok {
+		return category.assetType
+	}
+	return assetType
+}
+
+// assetTags returns the category, channel and content category of the device as asset tags
+func assetTags(spec hailo.Spec) []string {
+	var tags []string
+	for _, tag := range []string{spec.DeviceTypeSpecific.Category, spec.DeviceTypeSpecific.Channel, spec.DeviceTypeSpecific.ContentCategory} {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// updateAssetTags sets the tags of the device for the assets mapped in each project, if the tags have changed since
+// the last update. Tags previously set by the app are replaced, tags set by users are kept.
+func updateAssetTags(config apiserver.Configuration, spec hailo.Spec) error {
+	tags := assetTags(spec)
+	for _, projectId := range conf.ProjIds(config) {
+		assetId, err := mappedAssetId(config, projectId, spec.DeviceId)
+		if err != nil {
+			return err
+		}
+		previous, found, err := previousTags(assetId)
+		if err != nil {
+			return err
+		}
+		if found && equalTags(previous, tags) {
+			continue
+		}
+		if !found && len(tags) == 0 {
+			err = rememberTags(assetId, tags)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		log.Debug("Hailo", "Update tags of asset %d for device %s", assetId, spec.DeviceId)
+		err = updateAsset(assetId, func(apiAsset *api.Asset) {
+			apiAsset.Tags = mergeTags(apiAsset.Tags, previous, tags)
+		})
+		if err != nil {
+			return err
+		}
+		err = rememberTags(assetId, tags)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// previousTags returns the tags last set by the app for the asset. The tags are read from the database, if the app
+// has not set the tags since its start.
+func previousTags(assetId int32) ([]string, bool, error) {
+	if value, found := syncedTags.Load(assetId); found {
+		return value.([]string), true, nil
+	}
+	tags, err := conf.GetAssetTags(context.Background(), assetId)
+	if err != nil || tags == nil {
+		return nil, false, err
+	}
+	syncedTags.Store(assetId, tags)
+	return tags, true, nil
+}
+
+// rememberTags stores the tags set by the app for the asset
+func rememberTags(assetId int32, tags []string) error {
+	err := conf.SetAssetTags(context.Background(), assetId, tags)
+	if err != nil {
+		return err
+	}
+	syncedTags.Store(assetId, tags)
+	return nil
+}
+
+// mergeTags removes the tags previously set by the app from the current tags and adds the new tags
+func mergeTags(current []string, previous []string, tags []string) []string {
+	var merged []string
+	for _, tag := range current {
+		if !contains(previous, tag) || contains(tags, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	for _, tag := range tags {
+		if !contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
+
+func equalTags(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"hailo/apiserver"
+	"hailo/hailo"
+	"testing"
+)
+
+func TestContentCategories(t *testing.T) {
+	spec := hailo.Spec{DeviceId: "bin-1"}
+	spec.DeviceTypeSpecific.Category = "Waste"
+	spec.DeviceTypeSpecific.Channel = "Public"
+	spec.DeviceTypeSpecific.ContentCategory = "PET"
+	assert.Equal(t, []string{"Waste", "Public", "PET"}, assetTags(spec))
+
+	config := apiserver.Configuration{}
+	assert.Equal(t, BinAssetType, creationAssetType(config, spec))
+	config.ContentCategoryAssetTypes = common.Ptr(true)
+	assert.Equal(t, PetBinAssetType, creationAssetType(config, spec))
+	spec.DeviceTypeSpecific.ContentCategory = "Glass"
+	assert.Equal(t, BinAssetType, creationAssetType(config, spec))
+
+	assert.Equal(t, []string{"user", "Waste", "Glass"}, mergeTags([]string{"user", "Waste", "PET"}, []string{"Waste", "PET"}, []string{"Waste", "Glass"}))
+}
@@ -17,72 +17,192 @@ package eliona
 
 import (
 	"context"
+	"fmt"
 	"hailo/apiserver"
 	"hailo/conf"
 	"hailo/hailo"
 	"math"
-	"strconv"
 	"time"
 
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-eliona/client"
 	"github.com/eliona-smart-building-assistant/go-utils/common"
 	"github.com/eliona-smart-building-assistant/go-utils/log"
 )
 
+// UpsertDataForDevices writes the info data and the location of the device and all components defined in the
+// specification
 func UpsertDataForDevices(config apiserver.Configuration, spec hailo.Spec) error {
-
-	for _, projectId := range conf.ProjIds(config) {
-
-		err := upsertDataForDevice(config, projectId, spec)
+	position := deviceLocation(config, spec, nil)
+	err := upsertDataForDevice(config, spec, position)
+	if err != nil {
+		log.Error("Hailo", "Could not upsert data for device %s: %v", spec.DeviceId, err)
+		return err
+	}
+	for _, subSpec := range spec.DeviceTypeSpecific.ComponentIdList {
+		if SupportedDeviceKind(subSpec) == nil {
+			continue
+		}
+		err = upsertDataForDevice(config, subSpec, deviceLocation(config, subSpec, position))
 		if err != nil {
-			log.Error("Hailo", "Could not upsert data for device %s: %v", spec.DeviceId, err)
+			log.Error("Hailo", "Could not upsert data for sub device %s: %v", subSpec.DeviceId, err)
 			return err
 		}
-		for _, subSpec := range spec.DeviceTypeSpecific.ComponentIdList {
-			err = upsertDataForDevice(config, projectId, subSpec)
-			if err != nil {
-				log.Error("Hailo", "Could not upsert data for sub device %s: %v", subSpec.DeviceId, err)
-				return err
-			}
-		}
 	}
 	return nil
 }
 
-type deviceDataPayload struct {
-	RegistrationDate string `json:"reg_data"`
-	Volume           int    `json:"volume"`
-}
-
-func upsertData(subtype api.DataSubtype, time time.Time, assetId int32, payload any) error {
+// upsertData writes the payload to Eliona. If the payload and the timestamp are unchanged since the last write, the
+// write is skipped until the refresh interval of the configuration is exceeded. If the timestamp is unknown, the last
+// known timestamp is written or, if none is known, no timestamp. If the data cannot be written or older data waits in
+// the outbox, the data is queued in the outbox.
+func upsertData(config apiserver.Configuration, subtype api.DataSubtype, time time.Time, assetId int32, payload any) error {
+	data := common.StructToMap(payload)
+	if !isDataChanged(config, assetId, subtype, time, data) {
+		log.Debug("Hailo", "Skip writing unchanged %s data for asset %d", subtype, assetId)
+		return nil
+	}
 	var statusData api.Data
 	statusData.Subtype = subtype
-	statusData.Timestamp = *api.NewNullableTime(&time)
+	timestamp := time
+	if timestamp.IsZero() {
+		timestamp = lastKnownTimestamp(assetId, subtype)
+	}
+	if !timestamp.IsZero() {
+		statusData.Timestamp = *api.NewNullableTime(&timestamp)
+	}
 	statusData.AssetId = assetId
-	statusData.Data = common.StructToMap(payload)
-	err := asset.UpsertDataIfAssetExists(statusData)
+	statusData.Data = data
+	if !isOutboxPending() {
+		err := asset.UpsertDataIfAssetExists(statusData)
+		if err == nil {
+			rememberWrittenData(assetId, subtype, time, data)
+			return nil
+		}
+		log.Warn("Hailo", "Error during writing data, queue data in outbox: %v", err)
+	}
+	err := enqueueData(statusData)
 	if err != nil {
-		log.Error("Hailo", "Error during writing data: %v", err)
+		log.Error("Hailo", "Error during queuing data: %v", err)
 		return err
 	}
+	rememberWrittenData(assetId, subtype, time, data)
 	return nil
 }
 
-func upsertDataForDevice(config apiserver.Configuration, projectId string, spec hailo.Spec) error {
+// currentData reads the current data of the assets of the asset types from Eliona. The data of all subtypes is merged
+// for each asset id. Only the given assets are read, so no data of assets of other configurations is loaded. In
+// contrast to the data last written, the current data is also known after a restart.
+func currentData(assetIds []int32, assetTypes []string) (map[int32]map[string]interface{}, error) {
+	types := make(map[string]bool)
+	for _, assetType := range assetTypes {
+		types[assetType] = true
+	}
+	current := make(map[int32]map[string]interface{})
+	for _, assetId := range assetIds {
+		data, _, err := client.NewClient().DataAPI.
+			GetData(client.AuthenticationContext()).
+			AssetId(assetId).
+			Execute()
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range data {
+			if assetType := d.AssetTypeName.Get(); assetType != nil && !types[*assetType] {
+				continue
+			}
+			if current[d.AssetId] == nil {
+				current[d.AssetId] = make(map[string]interface{})
+			}
+			for attribute, value := range d.Data {
+				current[d.AssetId][attribute] = value
+			}
+		}
+	}
+	return current, nil
+}
+
+// configAssetIds returns the ids of the assets mapped for the projects of the configuration
+func configAssetIds(config apiserver.Configuration, assetMappings []apiserver.AssetMapping) []int32 {
+	projectIds := make(map[string]bool)
+	for _, projectId := range conf.ProjIds(config) {
+		projectIds[projectId] = true
+	}
+	var assetIds []int32
+	for _, assetMapping := range assetMappings {
+		if projectIds[assetMapping.ProjId] {
+			assetIds = append(assetIds, assetMapping.AssetId)
+		}
+	}
+	return assetIds
+}
+
+// upsertMappedData writes the attributes mapped for the asset type and the subtypes from the given sources to the
+// asset of the device in each project. Invalid values are added to the report.
+func upsertMappedData(config apiserver.Configuration, assetType string, deviceId string, time time.Time, sources mappingSources, report *Report, subtypes ...api.DataSubtype) error {
+	m := mapping{deviceId: deviceId, report: report}
+	mappings := attributeMappings(config)
+	payloads := make(map[api.DataSubtype]map[string]interface{})
+	for _, subtype := range subtypes {
+		payloads[subtype] = m.attributeData(mappings, assetType, subtype, sources)
+	}
+	for _, projectId := range conf.ProjIds(config) {
+		assetId, err := mappedAssetId(config, projectId, deviceId)
+		if err != nil {
+			return err
+		}
+		for _, subtype := range subtypes {
+			if len(payloads[subtype]) == 0 {
+				continue
+			}
+			err = upsertData(config, subtype, time, assetId, payloads[subtype])
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func upsertDataForDevice(config apiserver.Configuration, spec hailo.Spec, position *hailo.Position) error {
 	log.Debug("Hailo", "Upsert data for device: config %d and device '%s'", config.Id, spec.DeviceId)
-	assetId, err := conf.GetAssetId(context.Background(), config, projectId, spec.DeviceId)
+	err := updateAssetLocations(config, spec, position)
+	if err != nil {
+		return err
+	}
+	err = updateAssetTags(config, spec)
 	if err != nil {
 		return err
 	}
-	return upsertData(
+	appValues := locationData(position)
+	appValues["volume"] = binVolume(spec)
+	return upsertMappedData(
+		config,
+		assetType(spec),
+		spec.DeviceId,
+		dataTime(spec.Generic.RegistrationDate),
+		mappingSources{
+			sourceSpec: spec.Raw,
+			sourceApp:  appValues,
+		},
+		nil,
 		api.SUBTYPE_INFO,
-		parseTime(spec.Generic.RegistrationDate),
-		*assetId,
-		deviceDataPayload{RegistrationDate: spec.Generic.RegistrationDate, Volume: binVolume(spec)},
 	)
 }
 
+// mappedAssetId returns the asset id mapped to the device or an error, if no asset is mapped
+func mappedAssetId(config apiserver.Configuration, projectId string, deviceId string) (int32, error) {
+	assetId, err := conf.GetAssetId(context.Background(), config, projectId, deviceId)
+	if err != nil {
+		return 0, err
+	}
+	if assetId == nil {
+		return 0, fmt.Errorf("no asset mapped for device %s in project %s", deviceId, projectId)
+	}
+	return *assetId, nil
+}
+
 func binVolume(spec hailo.Spec) int {
 	binVolume := spec.DeviceTypeSpecific.BinVolume
 	if spec.DeviceTypeSpecific.TotalCombinedVolume != 0 {
@@ -91,155 +211,195 @@ func binVolume(spec hailo.Spec) int {
 	return binVolume
 }
 
-type stationDataPayload struct {
-	BatteryLevel     int     `json:"bat_level"`
-	LastContact      float64 `json:"last_contact"`
-	TotalOpenings    int     `json:"totalopenings"`
-	VolumePercentage int     `json:"volumepercent"`
-	Active           bool    `json:"active"`
+// UpsertDataForStation writes the status of the station. Invalid values are added to the report.
+func UpsertDataForStation(config apiserver.Configuration, status hailo.Status, report *Report) error {
+	log.Debug("Hailo", "Upsert data for station: config %d and station '%s'", config.Id, status.DeviceId)
+	lastContact := timeToHours(status.Generic.LastContact)
+	recordUsage(config, "station", status.DeviceId, status.DeviceTypeSpecific.TotalInputsCount, status.Generic.LastContact)
+	sources := mappingSources{
+		sourceStatus: status.Raw,
+		sourceApp: map[string]interface{}{
+			"active": CheckActivity(config, lastContact),
+			"alarm":  stationAlarm(status),
+		},
+	}
+	sources[sourceApp]["bat_days_left"] = forecastBattery(config, "station", RecyclingStationAssetType, status, sources)
+	err := upsertMappedData(
+		config,
+		RecyclingStationAssetType,
+		status.DeviceId,
+		dataTime(status.Generic.LastContact),
+		sources,
+		report,
+		api.SUBTYPE_INPUT,
+	)
+	if err != nil {
+		log.Error("Hailo", "Could not upsert data for station %s: %v", status.DeviceId, err)
+		return err
+	}
+	return nil
 }
 
-func UpsertDataForStation(config apiserver.Configuration, status hailo.Status) error {
-	for _, projectId := range conf.ProjIds(config) {
-		log.Debug("Hailo", "Upsert data for station: config %d and station '%s'", config.Id, status.DeviceId)
-		lastContact := parseTimeToHours(status.Generic.LastContact)
-		assetId, err := conf.GetAssetId(context.Background(), config, projectId, status.DeviceId)
-		if err != nil {
-			return err
-		}
-		err = upsertData(
-			api.SUBTYPE_INPUT,
-			parseTime(status.Generic.LastContact),
-			*assetId,
-			stationDataPayload{
-				int(interfaceToFloat(status.DeviceTypeSpecific.AverageBatteryLevel) * 100),
-				lastContact,
-				status.DeviceTypeSpecific.TotalInputsCount,
-				int(interfaceToFloat(status.DeviceTypeSpecific.AverageFillingLevel) * 100),
-				CheckActivity(config, lastContact),
-			},
-		)
-		if err != nil {
-			log.Error("Hailo", "Could not upsert data for station %s: %v", status.DeviceId, err)
-			return err
-		}
+// UpsertDiagForStation writes the diagnostic of the station like the expected fill level and the expected next
+// service. Invalid values are added to the report.
+func UpsertDiagForStation(config apiserver.Configuration, status hailo.Status, diag hailo.Diag, report *Report) error {
+	log.Debug("Hailo", "Upsert diag for station: config %d and station '%s'", config.Id, status.DeviceId)
+	err := upsertMappedData(
+		config,
+		RecyclingStationAssetType,
+		status.DeviceId,
+		dataTime(status.Generic.LastContact),
+		mappingSources{
+			sourceDiag: diag.Raw,
+			sourceApp:  map[string]interface{}{"sensor_errors": len(diag.Generic.SensorErrors)},
+		},
+		report,
+		api.SUBTYPE_STATUS,
+	)
+	if err != nil {
+		log.Error("Hailo", "Could not upsert diag for station %s: %v", status.DeviceId, err)
+		return err
 	}
 	return nil
 }
 
-func interfaceToFloat(value interface{}) float64 {
-	var fValue float64 = -1
-
-	switch v := value.(type) {
-	case string:
-		fValue, _ = strconv.ParseFloat(v, 32)
-	case int:
-		fValue = (float64)(v)
-	case float32:
-		fValue = (float64)(v)
-	case float64:
-		fValue = v
+// stationAlarm returns true, if any container of the station reports an alarm
+func stationAlarm(status hailo.Status) bool {
+	for _, compStatus := range status.DeviceTypeSpecific.CompStatuses {
+		if compStatus.DeviceTypeSpecific.BinAlarm {
+			return true
+		}
 	}
-	return fValue
+	return false
 }
 
-func CheckActivity(connection apiserver.Configuration, lastContact float64) bool {
-	return lastContact < (float64)(connection.InactiveTimeout/3600)
+// CheckActivity returns true, if the last contact is within the inactive timeout. Devices with unknown last
+// contact are inactive.
+func CheckActivity(connection apiserver.Configuration, lastContact *float64) bool {
+	return lastContact != nil && *lastContact < (float64)(connection.InactiveTimeout/3600)
 }
 
-type binDataPayload struct {
-	BatteryLevel     int     `json:"bat_level"`
-	Openings         int     `json:"openings"`
-	LastContact      float64 `json:"last_contact"`
-	Alarm            bool    `json:"alarm"`
-	TotalOpenings    int     `json:"totalopenings"`
-	VolumePercentage int     `json:"volumepercent"`
-	Time             float64 `json:"time"`
-	LastClean        float64 `json:"lastclean"`
-	Active           bool    `json:"active"`
+// fillingLevels summarizes the readings of all fill level sensors of a container. The fill level is the maximum
+// of all valid readings. If no sensor delivers a valid reading, the levels are nil and the number of sensors is 0.
+type fillingLevels struct {
+	max     *int
+	avg     *int
+	sensors int
 }
 
-type statusDataPayload struct {
-	ExpectedPercent int `json:"exp_percent"`
-}
+func fillingLevelData(m mapping, readings []hailo.FillingLevel) fillingLevels {
+	var levels fillingLevels
+	if len(readings) == 0 {
+		log.Debug("Hailo", "No fill level reading for device %s", m.deviceId)
+		return levels
+	}
 
-func UpsertDataForBin(config apiserver.Configuration, status hailo.Status, diag hailo.Diag) error {
-	for _, projectId := range conf.ProjIds(config) {
-		log.Debug("Hailo", "Upsert data for bin: config %d and bin '%s'", config.Id, status.DeviceId)
-		lastContact := parseTimeToHours(status.Generic.LastContact)
-		assetId, err := conf.GetAssetId(context.Background(), config, projectId, status.DeviceId)
-		if err != nil {
-			return err
+	var max, sum int
+	for i, reading := range readings {
+		level := m.percent(fmt.Sprintf("filling_level[%d]", i), float64(reading.Level), maxFillingLevel)
+		if level == nil {
+			continue
 		}
-		err = upsertData(
-			api.SUBTYPE_INPUT,
-			parseTime(status.Generic.LastContact),
-			*assetId,
-			binDataPayload{
-				int(status.DeviceTypeSpecific.BatteryLevel * 100),
-				status.DeviceTypeSpecific.LastEmptyCount,
-				lastContact,
-				status.DeviceTypeSpecific.BinAlarm,
-				status.DeviceTypeSpecific.InputCount,
-				int(status.DeviceTypeSpecific.FillingLevel[0].Level * 100),
-				parseTimeToDays(diag.DeviceTypeSpecific.ExpectedNextService),
-				parseTimeToDays(diag.Generic.LastService),
-				CheckActivity(config, lastContact),
-			},
-		)
-		if err != nil {
-			log.Error("Hailo", "Could not upsert data for bin %s: %v", status.DeviceId, err)
-			return err
-		}
-		assetId, err = conf.GetAssetId(context.Background(), config, projectId, status.DeviceId)
-		if err != nil {
-			return err
-		}
-		err = upsertData(
-			api.SUBTYPE_STATUS,
-			parseTime(status.Generic.LastContact),
-			*assetId,
-			statusDataPayload{int(diag.DeviceTypeSpecific.ExpectedFillingLevel * 100)},
-		)
-		if err != nil {
-			log.Error("Hailo", "Could not upsert data for bin %s: %v", status.DeviceId, err)
-			return err
+		if levels.sensors == 0 || *level > max {
+			max = *level
 		}
+		sum += *level
+		levels.sensors++
+	}
+	if levels.sensors > 0 {
+		levels.max = common.Ptr(max)
+		levels.avg = common.Ptr(sum / levels.sensors)
 	}
+	return levels
+}
 
+// UpsertDataForBin writes the status and the diagnostic of the container. Invalid values are added to the report.
+func UpsertDataForBin(config apiserver.Configuration, status hailo.Status, diag hailo.Diag, report *Report) error {
+	log.Debug("Hailo", "Upsert data for bin: config %d and bin '%s'", config.Id, status.DeviceId)
+	lastContact := timeToHours(status.Generic.LastContact)
+	levels := fillingLevelData(mapping{deviceId: status.DeviceId, report: report}, status.DeviceTypeSpecific.FillingLevel)
+	newReading := recordBinReading(config, status, diag, levels)
+	recordUsage(config, "bin", status.DeviceId, status.DeviceTypeSpecific.InputCount, status.Generic.LastContact)
+	sources := mappingSources{
+		sourceStatus: status.Raw,
+		sourceDiag:   diag.Raw,
+		sourceApp: map[string]interface{}{
+			"active":            CheckActivity(config, lastContact),
+			"volumepercent":     levels.max,
+			"volumepercent_avg": levels.avg,
+			"level_sensors":     levels.sensors,
+			"sensor_errors":     len(diag.Generic.SensorErrors),
+			"sensor_fault":      sensorFault(config, status.DeviceId, newReading),
+		},
+	}
+	sources[sourceApp]["bat_days_left"] = forecastBattery(config, "bin", BinAssetType, status, sources)
+	err := upsertMappedData(
+		config,
+		BinAssetType,
+		status.DeviceId,
+		dataTime(status.Generic.LastContact),
+		sources,
+		report,
+		api.SUBTYPE_INPUT, api.SUBTYPE_STATUS,
+	)
+	if err != nil {
+		log.Error("Hailo", "Could not upsert data for bin %s: %v", status.DeviceId, err)
+		return err
+	}
 	return nil
 }
 
-// 2021-01-26T09:16:16.000Z
-func parseTime(iso string) time.Time {
-	if iso == "" {
-		return time.Now()
-	}
-	t, err := time.Parse(time.RFC3339Nano, iso)
+// UpsertDataForHub writes the status and diagnostic of a Digital Hub gateway. Invalid values are added to the report.
+func UpsertDataForHub(config apiserver.Configuration, status hailo.Status, diag hailo.Diag, report *Report) error {
+	log.Debug("Hailo", "Upsert data for hub: config %d and hub '%s'", config.Id, status.DeviceId)
+	err := upsertMappedData(
+		config,
+		DigitalHubAssetType,
+		status.DeviceId,
+		dataTime(status.Generic.LastContact),
+		mappingSources{
+			sourceStatus: status.Raw,
+			sourceDiag:   diag.Raw,
+		},
+		report,
+		api.SUBTYPE_INPUT, api.SUBTYPE_STATUS,
+	)
 	if err != nil {
-		log.Warn("Hailo", "Error while converting ISO 8601 time to unix time %v, in: %s", err, iso)
-		return time.Now()
+		log.Error("Hailo", "Could not upsert data for hub %s: %v", status.DeviceId, err)
+		return err
 	}
-	return t
+	return nil
 }
 
-func parseToIsoTime(unix int64) string {
-	t := time.Unix(unix, 0)
-	return t.UTC().Format("2006-01-02T15:04:05.006Z")
+// dataTime returns the timestamp for data written to Eliona. If the timestamp is unknown, the zero time is returned.
+func dataTime(t hailo.Time) time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+	return t.Time
 }
 
-func parseTimeToDays(iso string) float64 {
-	return math.Round((parseTimeToHours(iso)*100)/24) / 100
+// timeToDays returns the days between the timestamp and now or nil, if the timestamp is unknown
+func timeToDays(t hailo.Time) *float64 {
+	hours := timeToHours(t)
+	if hours == nil {
+		return nil
+	}
+	return common.Ptr(math.Round((*hours*100)/24) / 100)
 }
 
-func parseTimeToHours(iso string) float64 {
+// timeToHours returns the full hours between the timestamp and now or nil, if the timestamp is unknown
+func timeToHours(t hailo.Time) *float64 {
+	if !t.Valid {
+		return nil
+	}
 	now := time.Now().Unix()
-	dst := parseTime(iso).Unix()
+	dst := t.Unix()
 	rst := 0.0
 	if now > dst {
 		rst = (float64)((now - dst) / (60 * 60))
 	} else {
 		rst = (float64)((dst - now) / (60 * 60))
 	}
-	return math.Round(rst*100) / 100
+	return common.Ptr(math.Round(rst*100) / 100)
 }
@@ -16,40 +16,176 @@
 package eliona
 
 import (
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
+	"hailo/apiserver"
+	"hailo/hailo"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
 )
 
-func TestTimeConvert(t *testing.T) {
-	tm := time.Now().Unix()
-	iso := parseToIsoTime(tm)
-	unix := parseTime(iso).Unix()
-	assert.Equal(t, tm, unix)
+func TestTimeSinceTimeUntil(t *testing.T) {
+
+	now := time.Now()
+	result := timeToHours(hailo.Time{Time: now.Add(-2 * time.Hour), Valid: true})
+	assert.Equal(t, 2.0, *result)
+
+	result = timeToHours(hailo.Time{Time: now.Add(8 * time.Hour), Valid: true})
+	assert.Equal(t, 8.0, *result)
+
+	result = timeToDays(hailo.Time{Time: now.Add(3 * 24 * time.Hour), Valid: true})
+	assert.Equal(t, 3.0, *result)
+
+	result = timeToDays(hailo.Time{Time: now.Add(-41 * 24 * time.Hour), Valid: true})
+	assert.Equal(t, 41.0, *result)
+
+	result = timeToHours(hailo.Time{Time: now.Add(-150 * time.Minute), Valid: true})
+	assert.Equal(t, 2.0, *result)
+
+	result = timeToDays(hailo.Time{Time: now.Add(-36 * time.Hour), Valid: true})
+	assert.Equal(t, 1.5, *result)
+
+	assert.Nil(t, timeToHours(hailo.Time{}))
+	assert.Nil(t, timeToDays(hailo.Time{}))
+	assert.False(t, CheckActivity(apiserver.Configuration{InactiveTimeout: 3600 * 24}, nil))
 }
 
-func TestTimeSinceTimeUntil(t *testing.T) {
+func TestDataTime(t *testing.T) {
+	lastContact := time.Date(2023, 1, 2, 10, 30, 0, 0, time.UTC)
+	assert.Equal(t, lastContact, dataTime(hailo.Time{Time: lastContact, Valid: true}))
+	assert.True(t, dataTime(hailo.Time{Time: lastContact}).IsZero())
+	assert.True(t, dataTime(hailo.Time{}).IsZero())
+}
 
-	unix := time.Now().Unix()
-	unix -= 2 * 60 * 60
-	iso := parseToIsoTime(unix)
-	result := parseTimeToHours(iso)
-	assert.Equal(t, 2.0, result)
+func TestFillingLevelData(t *testing.T) {
+	report := &Report{}
+	m := mapping{deviceId: "bin", report: report}
 
-	unix += 10 * 60 * 60
-	iso = parseToIsoTime(unix)
-	result = parseTimeToHours(iso)
-	assert.Equal(t, 8.0, result)
+	levels := fillingLevelData(m, nil)
+	assert.Equal(t, 0, levels.sensors)
+	assert.Nil(t, levels.max)
+	assert.Nil(t, levels.avg)
 
-	unix = time.Now().Unix()
-	unix += 3 * 24 * 60 * 60
-	iso = parseToIsoTime(unix)
-	result = parseTimeToDays(iso)
-	assert.Equal(t, 3.0, result)
+	levels = fillingLevelData(m, []hailo.FillingLevel{{Level: 0.25}, {Level: 0.75}, {Level: 0.5}})
+	assert.Equal(t, 3, levels.sensors)
+	assert.Equal(t, 75, *levels.max)
+	assert.Equal(t, 50, *levels.avg)
+	assert.Empty(t, report.Errors())
 
-	unix -= 44 * 24 * 60 * 60
-	iso = parseToIsoTime(unix)
-	result = parseTimeToDays(iso)
-	assert.Equal(t, 41.0, result)
+	levels = fillingLevelData(m, []hailo.FillingLevel{{Level: -1}, {Level: 0.5}})
+	assert.Equal(t, 1, levels.sensors)
+	assert.Equal(t, 50, *levels.max)
+	assert.Len(t, report.Errors(), 1)
+}
+
+func TestLevelSensorData(t *testing.T) {
+	var status hailo.Status
+	err := json.Unmarshal([]byte(`{"device_id":"bin","device_type_specific":{"filling_level":[{"level":0.5,"sensor_id":"s-1","position":"front","timestamp":"`+time.Now().Add(-3*time.Hour).UTC().Format(time.RFC3339)+`"}]}}`), &status)
+	assert.Nil(t, err)
+	mappings, err := readDefaultAttributeMappings("")
+	assert.Nil(t, err)
+	data := mapping{deviceId: "bin", report: &Report{}}.attributeData(mappings, BinAssetType, api.SUBTYPE_STATUS, mappingSources{sourceStatus: status.Raw})
+	assert.Equal(t, "s-1", data["level_sensor_1_id"])
+	assert.Equal(t, "front", data["level_sensor_1_position"])
+	assert.Equal(t, 3.0, *data["level_sensor_1_age"].(*float64))
+	assert.Nil(t, data["level_sensor_2_id"])
+}
+
+func TestAttributeData(t *testing.T) {
+	report := &Report{}
+	m := mapping{deviceId: "bin", report: report}
+
+	var status hailo.Status
+	err := json.Unmarshal([]byte(`{"device_id":"bin","device_type_specific":{"battery_level":0.29,"inputs_count":-3,"bin_alarm":true,"average_battery_level":"0.8","filling_level":[{"level":0.5},{"level":2.5}]}}`), &status)
+	assert.Nil(t, err)
+	sources := mappingSources{sourceStatus: status.Raw, sourceApp: map[string]interface{}{"active": true}}
+	mappings := []apiserver.AttributeMapping{
+		{AssetType: BinAssetType, Attribute: "bat_level", Subtype: "input", Source: "status", Path: "device_type_specific.battery_level", Scale: common.Ptr(100.0), Round: common.Ptr[int32](0), Min: common.Ptr(0.0), Max: common.Ptr(100.0)},
+		{AssetType: BinAssetType, Attribute: "station_bat", Subtype: "input", Source: "status", Path: "device_type_specific.average_battery_level", Scale: common.Ptr(100.0)},
+		{AssetType: BinAssetType, Attribute: "totalopenings", Subtype: "input", Source: "status", Path: "device_type_specific.inputs_count", Min: common.Ptr(0.0)},
+		{AssetType: BinAssetType, Attribute: "alarm", Subtype: "input", Source: "status", Path: "device_type_specific.bin_alarm"},
+		{AssetType: BinAssetType, Attribute: "volumepercent_1", Subtype: "input", Source: "status", Path: "device_type_specific.filling_level.0.level", Scale: common.Ptr(100.0), Max: common.Ptr(200.0)},
+		{AssetType: BinAssetType, Attribute: "volumepercent_2", Subtype: "input", Source: "status", Path: "device_type_specific.filling_level.1.level", Scale: common.Ptr(100.0), Max: common.Ptr(200.0)},
+		{AssetType: BinAssetType, Attribute: "volumepercent_3", Subtype: "input", Source: "status", Path: "device_type_specific.filling_level.2.level", Scale: common.Ptr(100.0), Max: common.Ptr(200.0)},
+		{AssetType: BinAssetType, Attribute: "active", Subtype: "input", Source: "app", Path: "active"},
+		{AssetType: BinAssetType, Attribute: "exp_percent", Subtype: "status", Source: "diag", Path: "device_type_specific.expected_filling_level"},
+		{AssetType: RecyclingStationAssetType, Attribute: "bat_level", Subtype: "input", Source: "status", Path: "device_type_specific.average_battery_level"},
+	}
+
+	data := m.attributeData(mappings, BinAssetType, api.SUBTYPE_INPUT, sources)
+	assert.Equal(t, map[string]interface{}{
+		"bat_level":       29.0,
+		"station_bat":     80.0,
+		"totalopenings":   nil,
+		"alarm":           true,
+		"volumepercent_1": 50.0,
+		"volumepercent_2": nil,
+		"volumepercent_3": nil,
+		"active":          true,
+	}, data)
+	assert.Len(t, report.Errors(), 2)
+}
+
+func TestMergeAttributeMappings(t *testing.T) {
+	defaults := []apiserver.AttributeMapping{
+		{AssetType: BinAssetType, Attribute: "bat_level", Subtype: "input", Source: "status", Path: "device_type_specific.battery_level"},
+		{AssetType: BinAssetType, Attribute: "alarm", Subtype: "input", Source: "status", Path: "device_type_specific.bin_alarm"},
+	}
+	overrides := []apiserver.AttributeMapping{
+		{AssetType: BinAssetType, Attribute: "bat_level", Subtype: "input", Source: "status", Path: "device_type_specific.battery", Scale: common.Ptr(1.0)},
+		{AssetType: BinAssetType, Attribute: "alarm", Disable: common.Ptr(true)},
+		{AssetType: BinAssetType, Attribute: "openings", Subtype: "input", Source: "status", Path: "device_type_specific.openings"},
+		{AssetType: BinAssetType, Attribute: "unknown", Subtype: "input", Source: "status", Path: "device_type_specific.unknown"},
+		{AssetType: BinAssetType, Attribute: "lid_temperature", Subtype: "input", Source: "status", Path: "device_type_specific.lid_temperature", AttributeType: common.Ptr("temperature")},
+		{AssetType: BinAssetType, Attribute: "lid_color", Subtype: "other", Source: "status", Path: "device_type_specific.lid_color", AttributeType: common.Ptr("device-info")},
+	}
+	var invalid []string
+	mappings := mergeAttributeMappings(defaults, overrides, func(attributeMapping apiserver.AttributeMapping, err error) {
+		invalid = append(invalid, attributeMapping.Attribute)
+	})
+	assert.Equal(t, []string{"unknown", "lid_color"}, invalid)
+	assert.Len(t, mappings, 3)
+	assert.Equal(t, "device_type_specific.battery", mappings[0].Path)
+	assert.Equal(t, "openings", mappings[1].Attribute)
+	assert.Equal(t, "lid_temperature", mappings[2].Attribute)
+}
+
+func TestReadAttributeMappingFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "mapping.yaml")
+	err := os.WriteFile(name, []byte(`
+- assetType: Hailo FDS Bin
+  attribute: lid_temperature
+  subtype: input
+  source: status
+  path: device_type_specific.lid_temperature
+  round: 1
+  attributeType: temperature
+  unit: "°C"
+`), 0o600)
+	assert.Nil(t, err)
+	mappings, err := readAttributeMappingFile(name)
+	assert.Nil(t, err)
+	assert.Len(t, mappings, 1)
+	assert.Equal(t, "lid_temperature", mappings[0].Attribute)
+	assert.Equal(t, int32(1), *mappings[0].Round)
+	assert.Equal(t, "°C", *mappings[0].Unit)
+
+	_, err = readAttributeMappingFile(filepath.Join(t.TempDir(), "mapping.txt"))
+	assert.NotNil(t, err)
+}
 
+func TestConfigAssetIds(t *testing.T) {
+	config := apiserver.Configuration{ProjIds: &[]string{"1", "2"}}
+	assetMappings := []apiserver.AssetMapping{
+		{ProjId: "1", DeviceId: "bin-1", AssetId: 11},
+		{ProjId: "2", DeviceId: "bin-1", AssetId: 21},
+		{ProjId: "3", DeviceId: "bin-1", AssetId: 31},
+	}
+	assert.Equal(t, []int32{11, 21}, configAssetIds(config, assetMappings))
+	assert.Nil(t, configAssetIds(apiserver.Configuration{}, assetMappings))
 }
@@ -0,0 +1,152 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"hailo/apiserver"
+	"hailo/hailo"
+	"strings"
+	"sync"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// DeviceKind describes how a kind of Hailo device is modelled in Eliona
+type DeviceKind struct {
+	Name      string
+	AssetType string
+
+	// assetTypes lists all asset types used for this kind, e.g. the asset types specific for a content category
+	assetTypes []string
+
+	// Station is true for devices grouping multiple component devices
+	Station bool
+
+	// upsertData writes the status and diagnostic of the device. The diagnostic is nil, if it could not be read.
+	upsertData func(config apiserver.Configuration, status hailo.Status, diag *hailo.Diag, report *Report) error
+}
+
+var (
+	binKind = DeviceKind{
+		Name:       "bin",
+		AssetType:  BinAssetType,
+		assetTypes: binAssetTypes,
+		upsertData: upsertBin,
+	}
+	stationKind = DeviceKind{
+		Name:       "station",
+		AssetType:  RecyclingStationAssetType,
+		assetTypes: []string{RecyclingStationAssetType},
+		Station:    true,
+		upsertData: upsertStation,
+	}
+	hubKind = DeviceKind{
+		Name:       "hub",
+		AssetType:  DigitalHubAssetType,
+		assetTypes: []string{DigitalHubAssetType},
+		upsertData: upsertHub,
+	}
+)
+
+// deviceKinds lists all supported kinds of Hailo devices
+var deviceKinds = []DeviceKind{binKind, stationKind, hubKind}
+
+// deviceTypes maps the normalized device type of the specification to the kind of device
+var deviceTypes = map[string]DeviceKind{
+	"bin":               binKind,
+	"container":         binKind,
+	"single_container":  binKind,
+	"station":           stationKind,
+	"recycling_station": stationKind,
+	"hub":               hubKind,
+	"digital_hub":       hubKind,
+	"hub_gateway":       hubKind,
+	"gateway":           hubKind,
+}
+
+// DeviceKindOf returns the kind of the device defined by the device type of the specification or nil, if the device
+// type is unknown. Devices without device type are stations if they list components, otherwise bins.
+func DeviceKindOf(spec hailo.Spec) *DeviceKind {
+	deviceType := strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(spec.Generic.DeviceType)))
+	if deviceType == "" {
+		if spec.DeviceTypeSpecific.ComponentIdList != nil {
+			return &stationKind
+		}
+		return &binKind
+	}
+	if kind, ok := deviceTypes[deviceType]; ok {
+		return &kind
+	}
+	return nil
+}
+
+// deviceKindByName returns the kind of device with the given name, e.g. used in asset queries of dashboard templates
+func deviceKindByName(name string) (DeviceKind, bool) {
+	for _, kind := range deviceKinds {
+		if kind.Name == name {
+			return kind, true
+		}
+	}
+	return DeviceKind{}, false
+}
+
+// unknownDeviceTypes holds the unknown device types already logged
+var unknownDeviceTypes sync.Map
+
+// SupportedDeviceKind returns the kind of the device like DeviceKindOf. Devices with unknown device type are skipped
+// by the app, so each unknown device type is logged once.
+func SupportedDeviceKind(spec hailo.Spec) *DeviceKind {
+	kind := DeviceKindOf(spec)
+	if kind == nil {
+		if _, logged := unknownDeviceTypes.LoadOrStore(spec.Generic.DeviceType, true); !logged {
+			log.Warn("Hailo", "Skip devices with unknown device type '%s', e.g. device %s", spec.Generic.DeviceType, spec.DeviceId)
+		}
+	}
+	return kind
+}
+
+// AssetTypes returns all asset types used for assets of this kind
+func (kind DeviceKind) AssetTypes() []string {
+	return kind.assetTypes
+}
+
+// UpsertData writes the status and diagnostic of a device of this kind. The diagnostic is nil, if it could not be
+// read.
+func (kind DeviceKind) UpsertData(config apiserver.Configuration, status hailo.Status, diag *hailo.Diag, report *Report) error {
+	return kind.upsertData(config, status, diag, report)
+}
+
+func upsertBin(config apiserver.Configuration, status hailo.Status, diag *hailo.Diag, report *Report) error {
+	if diag == nil {
+		diag = &hailo.Diag{}
+	}
+	return UpsertDataForBin(config, status, *diag, report)
+}
+
+func upsertStation(config apiserver.Configuration, status hailo.Status, diag *hailo.Diag, report *Report) error {
+	err := UpsertDataForStation(config, status, report)
+	if err != nil || diag == nil {
+		return err
+	}
+	return UpsertDiagForStation(config, status, *diag, report)
+}
+
+func upsertHub(config apiserver.Configuration, status hailo.Status, diag *hailo.Diag, report *Report) error {
+	if diag == nil {
+		diag = &hailo.Diag{}
+	}
+	return UpsertDataForHub(config, status, *diag, report)
+}
@@ -0,0 +1,38 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"github.com/stretchr/testify/assert"
+	"hailo/hailo"
+	"testing"
+)
+
+func TestDeviceKindOf(t *testing.T) {
+	spec := hailo.Spec{DeviceId: "bin-1"}
+	assert.Equal(t, BinAssetType, DeviceKindOf(spec).AssetType)
+
+	spec.DeviceTypeSpecific.ComponentIdList = []hailo.Spec{{DeviceId: "bin-2"}}
+	assert.Equal(t, RecyclingStationAssetType, DeviceKindOf(spec).AssetType)
+	assert.True(t, DeviceKindOf(spec).Station)
+
+	spec.Generic.DeviceType = "Digital-Hub"
+	assert.Equal(t, DigitalHubAssetType, DeviceKindOf(spec).AssetType)
+
+	spec.Generic.DeviceType = "Compactor"
+	assert.Nil(t, DeviceKindOf(spec))
+	assert.Equal(t, "", assetType(spec))
+}
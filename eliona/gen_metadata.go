@@ -0,0 +1,53 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+//go:build ignore
+
+// Registers all dashboard templates in the dashboardTemplateNames of metadata.json. Run with go generate.
+package main
+
+import (
+	"encoding/json"
+	"hailo/eliona"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+const metadataFile = "../metadata.json"
+
+func main() {
+	names, err := eliona.DashboardTemplateNames()
+	if err != nil {
+		log.Fatal("Hailo", "Could not read dashboard templates: %v", err)
+	}
+	content, err := os.ReadFile(metadataFile)
+	if err != nil {
+		log.Fatal("Hailo", "Could not read %s: %v", metadataFile, err)
+	}
+	var quoted []string
+	for _, name := range names {
+		value, _ := json.Marshal(name)
+		quoted = append(quoted, "    "+string(value))
+	}
+	pattern := regexp.MustCompile(`"dashboardTemplateNames": \[[^\]]*\]`)
+	content = pattern.ReplaceAll(content, []byte("\"dashboardTemplateNames\": [\n"+strings.Join(quoted, ",\n")+"\n  ]"))
+	err = os.WriteFile(metadataFile, content, 0644)
+	if err != nil {
+		log.Fatal("Hailo", "Could not write %s: %v", metadataFile, err)
+	}
+}
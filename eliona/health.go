@@ -0,0 +1,120 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"math"
+	"sort"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+)
+
+// deviceHealth summarizes the current data of a device as needed by the maintenance crew. Missing values do
+// not count as problem, except a missing activity, because devices with unknown last contact are inactive.
+type deviceHealth struct {
+	problems    int
+	battery     *float64
+	lastContact *float64
+}
+
+// healthOf returns the health of the device from the current data of the asset
+func healthOf(data map[string]interface{}) deviceHealth {
+	var health deviceHealth
+	if dataBool(data["alarm"]) {
+		health.problems++
+	}
+	if !dataBool(data["active"]) {
+		health.problems++
+	}
+	if sensorErrors, ok := dataNumber(data["sensor_errors"]); ok && sensorErrors > 0 {
+		health.problems++
+	}
+	if dataBool(data["sensor_fault"]) {
+		health.problems++
+	}
+	if battery, ok := dataNumber(data["bat_level"]); ok {
+		health.battery = &battery
+	}
+	if lastContact, ok := dataNumber(data["last_contact"]); ok {
+		health.lastContact = &lastContact
+	}
+	return health
+}
+
+// worseThan returns true, if the health is worse than the other one. Devices with more problems are worse, then
+// devices with lower battery level and then devices with older last contact. Unknown values are never worse.
+func (health deviceHealth) worseThan(other deviceHealth) bool {
+	if health.problems != other.problems {
+		return health.problems > other.problems
+	}
+	if battery, otherBattery := valueOr(health.battery, math.Inf(1)), valueOr(other.battery, math.Inf(1)); battery != otherBattery {
+		return battery < otherBattery
+	}
+	return valueOr(health.lastContact, math.Inf(-1)) > valueOr(other.lastContact, math.Inf(-1))
+}
+
+// valueOr returns the value or the given value for unknown values
+func valueOr(value *float64, unknown float64) float64 {
+	if value == nil {
+		return unknown
+	}
+	return *value
+}
+
+// sortByHealth sorts the assets worst-first by the health of the devices. The health is taken from the current data
+// of the assets read from Eliona, so the order is also valid right after a restart of the app.
+func sortByHealth(assets []api.Asset, data map[int32]map[string]interface{}) {
+	health := make(map[int32]deviceHealth)
+	for _, asset := range assets {
+		health[asset.GetId()] = healthOf(data[asset.GetId()])
+	}
+	sort.SliceStable(assets, func(i, j int) bool {
+		return health[assets[i].GetId()].worseThan(health[assets[j].GetId()])
+	})
+}
+
+// dataNumber returns the written value as float. If the value is missing or not a number, false is returned.
+func dataNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case *int:
+		if v != nil {
+			return float64(*v), true
+		}
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case *float64:
+		if v != nil {
+			return *v, true
+		}
+	}
+	return 0, false
+}
+
+// dataBool returns the written value as bool. Numbers other than 0 are true.
+func dataBool(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case *bool:
+		return v != nil && *v
+	}
+	number, ok := dataNumber(value)
+	return ok && number != 0
+}
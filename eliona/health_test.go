@@ -0,0 +1,46 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"testing"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+)
+
+func TestSortByHealth(t *testing.T) {
+	data := map[int32]map[string]interface{}{
+		5001: {"active": true, "alarm": false, "bat_level": 80.0, "last_contact": 1.0},
+		5002: {"active": true, "alarm": true, "bat_level": 90.0, "last_contact": 1.0},
+		5003: {"active": true, "alarm": false, "bat_level": 20.0, "last_contact": 2.0},
+		5004: {"active": true, "alarm": false, "bat_level": 80.0, "last_contact": 5.0},
+		5005: {"active": false, "alarm": false, "bat_level": 80.0, "last_contact": 50.0, "sensor_errors": 2.0},
+	}
+
+	var assets []api.Asset
+	for _, assetId := range []int32{5001, 5002, 5003, 5004, 5005, 5006} {
+		assets = append(assets, api.Asset{Id: *api.NewNullableInt32(common.Ptr(assetId))})
+	}
+	sortByHealth(assets, data)
+
+	var sorted []int32
+	for _, asset := range assets {
+		sorted = append(sorted, asset.GetId())
+	}
+	assert.Equal(t, []int32{5005, 5002, 5006, 5003, 5004, 5001}, sorted)
+}
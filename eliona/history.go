@@ -0,0 +1,95 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"context"
+	"hailo/apiserver"
+	"hailo/conf"
+	"hailo/hailo"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// readingRetention returns the time after which readings are deleted from the history, defined by the environment
+// variable READING_RETENTION_DAYS (default 400 days, so that reports can compare with the previous year)
+func readingRetention() time.Duration {
+	days, err := strconv.Atoi(common.Getenv("READING_RETENTION_DAYS", "400"))
+	if err != nil {
+		log.Warn("Hailo", "Invalid READING_RETENTION_DAYS: %v", err)
+		days = 400
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// recordedReadings holds the timestamp of the reading last stored for each bin
+var recordedReadings sync.Map
+
+// recordBinReading stores the reading of the bin in the history and returns true, if a new reading was stored.
+// Readings without last contact are skipped, because the time of the reading is unknown. Readings with the timestamp
+// of the reading last stored are skipped as well.
+func recordBinReading(config apiserver.Configuration, status hailo.Status, diag hailo.Diag, levels fillingLevels) bool {
+	if !status.Generic.LastContact.Valid {
+		return false
+	}
+	key := registeredDeviceKey{common.Val(config.Id), status.DeviceId}
+	if value, found := recordedReadings.Load(key); found && value.(time.Time).Equal(status.Generic.LastContact.Time) {
+		return false
+	}
+	reading := conf.Reading{
+		ConfigId:  common.Val(config.Id),
+		DeviceId:  status.DeviceId,
+		Timestamp: status.Generic.LastContact.Time,
+		Openings:  common.Ptr(int32(status.DeviceTypeSpecific.LastEmptyCount)),
+	}
+	if levels.max != nil {
+		reading.Volumepercent = common.Ptr(float64(*levels.max))
+	}
+	if diag.Generic.LastService.Valid {
+		reading.LastService = common.Ptr(diag.Generic.LastService.Time)
+	}
+	if device, ok := registeredDeviceOf(config, status.DeviceId); ok {
+		reading.StationId = device.parentId
+		reading.ContentCategory = strings.ToLower(strings.TrimSpace(device.spec.DeviceTypeSpecific.ContentCategory))
+		if device.spec.DeviceTypeSpecific.BinVolume > 0 {
+			reading.BinVolume = common.Ptr(int32(device.spec.DeviceTypeSpecific.BinVolume))
+		}
+	}
+	err := conf.InsertReading(context.Background(), reading)
+	if err != nil {
+		log.Error("Hailo", "Could not store reading for bin %s: %v", status.DeviceId, err)
+		return false
+	}
+	recordedReadings.Store(key, status.Generic.LastContact.Time)
+	return true
+}
+
+// DeleteExpiredReadings removes readings older than the retention time from the history
+func DeleteExpiredReadings() {
+	deleted, err := conf.DeleteExpiredReadings(context.Background(), time.Now().Add(-readingRetention()))
+	if err != nil {
+		log.Error("Hailo", "Could not delete expired readings: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Debug("Hailo", "Deleted %d expired readings", deleted)
+	}
+}
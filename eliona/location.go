@@ -0,0 +1,183 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"context"
+	"hailo/apiserver"
+	"hailo/conf"
+	"hailo/hailo"
+	"sort"
+	"sync"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// geoJsonAttributes are the input attributes of a bin added to the properties of the GeoJSON features
+var geoJsonAttributes = []string{"volumepercent", "alarm", "fire_alarm", "tilt_alarm"}
+
+// assetLocations holds the location last set for each asset id
+var assetLocations sync.Map
+
+// deviceLocation returns the location of the device. A location overridden by the asset mapping takes precedence
+// over the location read from the specification. Components without a location use the location of the parent.
+func deviceLocation(config apiserver.Configuration, spec hailo.Spec, parent *hailo.Position) *hailo.Position {
+	override, err := conf.GetDeviceLocation(context.Background(), config, spec.DeviceId)
+	if err != nil {
+		log.Warn("Hailo", "Could not read location override for device %s: %v", spec.DeviceId, err)
+	}
+	return effectiveLocation(override, spec.Generic.Location, parent)
+}
+
+// effectiveLocation combines the override, the location from the specification and the location of the parent
+func effectiveLocation(override *apiserver.Location, specified *hailo.Position, parent *hailo.Position) *hailo.Position {
+	var position hailo.Position
+	switch {
+	case specified != nil:
+		position = *specified
+	case parent != nil:
+		position = *parent
+	}
+	if override != nil {
+		if override.Latitude != nil && override.Longitude != nil {
+			position.Latitude = override.Latitude
+			position.Longitude = override.Longitude
+		}
+		if override.Address != nil {
+			position.Address = *override.Address
+		}
+	}
+	if position.Latitude == nil && position.Longitude == nil && position.Address == "" {
+		return nil
+	}
+	return &position
+}
+
+// locationData returns the location as app values for the attribute mapping
+func locationData(position *hailo.Position) map[string]interface{} {
+	data := map[string]interface{}{}
+	if position == nil {
+		return data
+	}
+	if position.Latitude != nil && position.Longitude != nil {
+		data["latitude"] = *position.Latitude
+		data["longitude"] = *position.Longitude
+	}
+	if position.Address != "" {
+		data["address"] = position.Address
+	}
+	return data
+}
+
+// updateAssetLocations sets the location of the assets mapped to the device in each project, if the location has
+// changed since the last update
+func updateAssetLocations(config apiserver.Configuration, spec hailo.Spec, position *hailo.Position) error {
+	if position == nil || position.Latitude == nil || position.Longitude == nil {
+		return nil
+	}
+	for _, projectId := range conf.ProjIds(config) {
+		assetId, err := mappedAssetId(config, projectId, spec.DeviceId)
+		if err != nil {
+			return err
+		}
+		if value, found := assetLocations.Load(assetId); found && samePosition(value.(hailo.Position), *position) {
+			continue
+		}
+		log.Debug("Hailo", "Update location of asset %d for device %s", assetId, spec.DeviceId)
+		err = updateAsset(assetId, func(apiAsset *api.Asset) {
+			apiAsset.Latitude = *api.NewNullableFloat64(position.Latitude)
+			apiAsset.Longitude = *api.NewNullableFloat64(position.Longitude)
+		})
+		if err != nil {
+			return err
+		}
+		assetLocations.Store(assetId, *position)
+	}
+	return nil
+}
+
+func samePosition(a hailo.Position, b hailo.Position) bool {
+	return a.Address == b.Address && sameFloat(a.Latitude, b.Latitude) && sameFloat(a.Longitude, b.Longitude)
+}
+
+func sameFloat(a *float64, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// GeoJson returns all located bins of the configuration as GeoJSON feature collection. The assets are read from the
+// asset mappings and the location and the properties from the current data in Eliona, so the map is complete even
+// if the devices were not processed since the start of the app.
+func GeoJson(config apiserver.Configuration) (apiserver.FeatureCollection, error) {
+	assetMappings, err := conf.GetAssetMappings(context.Background(), common.Val(config.Id))
+	if err != nil {
+		return apiserver.FeatureCollection{}, err
+	}
+	data, err := currentData(configAssetIds(config, assetMappings), binAssetTypes)
+	if err != nil {
+		return apiserver.FeatureCollection{}, err
+	}
+	return geoJson(assetMappings, data), nil
+}
+
+// geoJson builds the feature collection from the asset mappings and the current data of the bins. Assets without
+// current bin data are no bins and skipped. A location overridden by the asset mapping takes precedence.
+func geoJson(assetMappings []apiserver.AssetMapping, data map[int32]map[string]interface{}) apiserver.FeatureCollection {
+	sort.Slice(assetMappings, func(i, j int) bool { return assetMappings[i].AssetId < assetMappings[j].AssetId })
+
+	features := []apiserver.Feature{}
+	for _, assetMapping := range assetMappings {
+		assetData, found := data[assetMapping.AssetId]
+		if !found {
+			continue
+		}
+		latitude, latitudeOk := dataNumber(assetData["latitude"])
+		longitude, longitudeOk := dataNumber(assetData["longitude"])
+		if assetMapping.Latitude != nil && assetMapping.Longitude != nil {
+			latitude, latitudeOk = *assetMapping.Latitude, true
+			longitude, longitudeOk = *assetMapping.Longitude, true
+		}
+		if !latitudeOk || !longitudeOk {
+			continue
+		}
+		properties := map[string]interface{}{
+			"assetId":  assetMapping.AssetId,
+			"projId":   assetMapping.ProjId,
+			"deviceId": assetMapping.DeviceId,
+		}
+		if assetMapping.Address != nil {
+			properties["address"] = *assetMapping.Address
+		} else if address, ok := assetData["address"].(string); ok && address != "" {
+			properties["address"] = address
+		}
+		for _, attribute := range geoJsonAttributes {
+			properties[attribute] = assetData[attribute]
+		}
+		features = append(features, apiserver.Feature{
+			Type: "Feature",
+			Geometry: apiserver.Geometry{
+				Type:        "Point",
+				Coordinates: []float64{longitude, latitude},
+			},
+			Properties: properties,
+		})
+	}
+	return apiserver.FeatureCollection{Type: "FeatureCollection", Features: features}
+}
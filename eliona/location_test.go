@@ -0,0 +1,70 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"hailo/apiserver"
+	"hailo/hailo"
+	"testing"
+)
+
+func TestEffectiveLocation(t *testing.T) {
+	specified := &hailo.Position{Latitude: common.Ptr(47.37), Longitude: common.Ptr(8.54), Address: "Bahnhofstrasse 1"}
+	parent := &hailo.Position{Latitude: common.Ptr(47.0), Longitude: common.Ptr(8.0)}
+
+	assert.Nil(t, effectiveLocation(nil, nil, nil))
+	assert.Equal(t, specified, effectiveLocation(nil, specified, parent))
+	assert.Equal(t, parent, effectiveLocation(nil, nil, parent))
+
+	position := effectiveLocation(&apiserver.Location{Latitude: common.Ptr(46.0), Longitude: common.Ptr(7.0)}, specified, nil)
+	assert.Equal(t, 46.0, *position.Latitude)
+	assert.Equal(t, 7.0, *position.Longitude)
+	assert.Equal(t, "Bahnhofstrasse 1", position.Address)
+
+	position = effectiveLocation(&apiserver.Location{Latitude: common.Ptr(46.0), Address: common.Ptr("Paradeplatz")}, specified, nil)
+	assert.Equal(t, 47.37, *position.Latitude)
+	assert.Equal(t, "Paradeplatz", position.Address)
+}
+
+func TestGeoJson(t *testing.T) {
+	assetMappings := []apiserver.AssetMapping{
+		{ConfigId: 7, ProjId: "99", DeviceId: "bin-2", AssetId: 4713, Latitude: common.Ptr(47.4), Longitude: common.Ptr(8.5), Address: common.Ptr("Paradeplatz")},
+		{ConfigId: 7, ProjId: "99", DeviceId: "bin-1", AssetId: 4711},
+		{ConfigId: 7, ProjId: "99", DeviceId: "station-1", AssetId: 4712},
+		{ConfigId: 7, ProjId: "99", DeviceId: "unlocated", AssetId: 4714},
+	}
+	data := map[int32]map[string]interface{}{
+		4711: {"latitude": 47.37, "longitude": 8.54, "address": "Bahnhofstrasse 1", "volumepercent": 42.0, "alarm": 1.0},
+		4713: {"latitude": 47.37, "longitude": 8.54, "volumepercent": 10.0},
+		4714: {"volumepercent": 90.0},
+	}
+
+	collection := geoJson(assetMappings, data)
+	assert.Equal(t, "FeatureCollection", collection.Type)
+	assert.Len(t, collection.Features, 2)
+	assert.Equal(t, []float64{8.54, 47.37}, collection.Features[0].Geometry.Coordinates)
+	assert.Equal(t, int32(4711), collection.Features[0].Properties["assetId"])
+	assert.Equal(t, "Bahnhofstrasse 1", collection.Features[0].Properties["address"])
+	assert.Equal(t, 42.0, collection.Features[0].Properties["volumepercent"])
+	assert.Equal(t, 1.0, collection.Features[0].Properties["alarm"])
+	assert.Nil(t, collection.Features[0].Properties["fire_alarm"])
+	assert.Equal(t, []float64{8.5, 47.4}, collection.Features[1].Geometry.Coordinates)
+	assert.Equal(t, "Paradeplatz", collection.Features[1].Properties["address"])
+
+	assert.Empty(t, geoJson(nil, data).Features)
+}
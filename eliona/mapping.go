@@ -0,0 +1,165 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"fmt"
+	"hailo/apiserver"
+	"hailo/hailo"
+	"math"
+	"strconv"
+	"strings"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+)
+
+// maxFillingLevel is the highest plausible fill level. Overfilled containers can report more than 100 %.
+const maxFillingLevel = 2.0
+
+// mapping converts values read from FDS into typed readings written to Eliona. Invalid values are added as problems
+// to the report and mapped to nil, so that they are written as null instead of wrong data.
+type mapping struct {
+	deviceId string
+	report   *Report
+}
+
+// attributeData evaluates all attribute mappings for the asset type and subtype with the given sources
+func (m mapping) attributeData(mappings []apiserver.AttributeMapping, assetType string, subtype api.DataSubtype, sources mappingSources) map[string]interface{} {
+	data := make(map[string]interface{})
+	for _, attributeMapping := range mappings {
+		if attributeMapping.AssetType != assetType || attributeMapping.Subtype != string(subtype) {
+			continue
+		}
+		source, ok := sources[attributeMapping.Source]
+		if !ok {
+			continue
+		}
+		data[attributeMapping.Attribute] = m.value(attributeMapping, source)
+	}
+	return data
+}
+
+// value reads the value defined by the attribute mapping from the source and converts, scales, checks and rounds
+// the value as defined
+func (m mapping) value(attributeMapping apiserver.AttributeMapping, source map[string]interface{}) interface{} {
+	value := lookup(source, attributeMapping.Path)
+	if value == nil {
+		return nil
+	}
+
+	if attributeMapping.Conversion != nil {
+		t, err := hailo.ParseTime(timeString(value))
+		if err != nil {
+			m.report.AddError(m.deviceId, fmt.Errorf("%s is not a timestamp: %v", attributeMapping.Path, value))
+			return nil
+		}
+		switch *attributeMapping.Conversion {
+		case conversionHours:
+			return timeToHours(t)
+		case conversionDays:
+			return timeToDays(t)
+		}
+		return nil
+	}
+
+	if attributeMapping.Scale == nil && attributeMapping.Round == nil && attributeMapping.Min == nil && attributeMapping.Max == nil {
+		return value
+	}
+	number, ok := m.number(attributeMapping.Path, value)
+	if !ok {
+		return nil
+	}
+	if attributeMapping.Scale != nil {
+		number *= *attributeMapping.Scale
+	}
+	if (attributeMapping.Min != nil && number < *attributeMapping.Min) || (attributeMapping.Max != nil && number > *attributeMapping.Max) {
+		m.report.AddError(m.deviceId, fmt.Errorf("%s out of range: %v", attributeMapping.Path, number))
+		return nil
+	}
+	if attributeMapping.Round != nil {
+		factor := math.Pow(10, float64(*attributeMapping.Round))
+		number = math.Round(number*factor) / factor
+	}
+	return number
+}
+
+// timeString returns the timestamp as string. Timestamps can be strings or numbers (unix time in milliseconds).
+func timeString(value interface{}) string {
+	if number, ok := value.(float64); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// lookup returns the value for the dot separated path in the source or nil, if the path does not exist
+func lookup(source map[string]interface{}, path string) interface{} {
+	var value interface{} = source
+	for _, key := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = v[key]
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil
+			}
+			value = v[index]
+		default:
+			return nil
+		}
+	}
+	return value
+}
+
+// percent converts a ratio between 0 and max to an integer percentage. The percentage is rounded, because ratios read
+// as float32 are not exact (e.g. 0.29 is 0.2899999).
+func (m mapping) percent(attribute string, value float64, max float64) *int {
+	if value < 0 || value > max {
+		m.report.AddError(m.deviceId, fmt.Errorf("%s out of range: %v", attribute, value))
+		return nil
+	}
+	percent := int(math.Round(value * 100))
+	return &percent
+}
+
+// number converts a value of an unknown JSON type to a float. If the value is missing or not a number, false
+// is returned.
+func (m mapping) number(attribute string, value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case nil:
+		return 0, false
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			m.report.AddError(m.deviceId, fmt.Errorf("%s is not a number: '%s'", attribute, v))
+			return 0, false
+		}
+		return f, true
+	case int:
+		return float64(v), true
+	case *int:
+		if v == nil {
+			return 0, false
+		}
+		return float64(*v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	m.report.AddError(m.deviceId, fmt.Errorf("%s has unexpected type %T", attribute, value))
+	return 0, false
+}
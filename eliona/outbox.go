@@ -0,0 +1,168 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"context"
+	"hailo/conf"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// outboxBatchSize is the number of queued data read from the outbox at once
+const outboxBatchSize = 100
+
+// maxOutboxAttempts is the number of failed attempts after which queued data is discarded. Only attempts in replays
+// which could write other data count, so that data is kept as long as Eliona is not reachable at all.
+const maxOutboxAttempts = 5
+
+// outboxPending is set to 1 while data waits in the outbox. As long as data is pending, new data is also queued to
+// keep the order of the data written to Eliona.
+var outboxPending int32
+
+// outboxRetention returns the time after which queued data is discarded, defined by the environment variable
+// OUTBOX_RETENTION_HOURS (default 7 days)
+func outboxRetention() time.Duration {
+	hours, err := strconv.Atoi(common.Getenv("OUTBOX_RETENTION_HOURS", "168"))
+	if err != nil {
+		log.Warn("Hailo", "Invalid OUTBOX_RETENTION_HOURS: %v", err)
+		hours = 168
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// isOutboxPending returns true, if data waits in the outbox to be written
+func isOutboxPending() bool {
+	return atomic.LoadInt32(&outboxPending) == 1
+}
+
+// InitOutboxPending sets data pending, if the outbox still contains data queued before the start of the app
+func InitOutboxPending() {
+	count, err := conf.CountQueuedData(context.Background())
+	if err != nil {
+		log.Error("Hailo", "Could not count data in outbox: %v", err)
+		return
+	}
+	if count > 0 {
+		log.Info("Hailo", "Found %d data in outbox to write", count)
+		atomic.StoreInt32(&outboxPending, 1)
+	}
+}
+
+// enqueueData stores the data in the outbox to write it later. Data is set pending before and after storing, so that
+// a replay finishing in between can't reset it.
+func enqueueData(data api.Data) error {
+	atomic.StoreInt32(&outboxPending, 1)
+	err := conf.EnqueueData(context.Background(), data)
+	atomic.StoreInt32(&outboxPending, 1)
+	return err
+}
+
+// resetOutboxPending resets pending data after the outbox was found empty. If data was queued in the meantime, the
+// data is set pending again.
+func resetOutboxPending(ctx context.Context) {
+	if !atomic.CompareAndSwapInt32(&outboxPending, 1, 0) {
+		return
+	}
+	count, err := conf.CountQueuedData(ctx)
+	if err != nil || count > 0 {
+		atomic.StoreInt32(&outboxPending, 1)
+	}
+}
+
+// ReplayQueuedData writes the data queued in the outbox to Eliona in order of the timestamps. Expired data is
+// discarded. Data which can't be written is skipped and counted as failed attempt, if other data could be written.
+// If no data can be written at all, Eliona is not reachable and the replay continues with the next call.
+func ReplayQueuedData() {
+	ctx := context.Background()
+
+	expired, err := conf.DeleteExpiredQueuedData(ctx, time.Now().Add(-outboxRetention()))
+	if err != nil {
+		log.Error("Hailo", "Could not delete expired data from outbox: %v", err)
+		return
+	}
+	if expired > 0 {
+		log.Warn("Hailo", "Discarded %d expired data from outbox", expired)
+	}
+
+	skipped := 0
+	for {
+		queuedData, err := conf.GetQueuedData(ctx, skipped, outboxBatchSize)
+		if err != nil {
+			log.Error("Hailo", "Could not read data from outbox: %v", err)
+			return
+		}
+		if len(queuedData) == 0 {
+			if skipped == 0 {
+				resetOutboxPending(ctx)
+			}
+			return
+		}
+		atomic.StoreInt32(&outboxPending, 1)
+		var failed []conf.QueuedData
+		written := 0
+		for _, queued := range queuedData {
+			err = asset.UpsertDataIfAssetExists(queued.Data)
+			if err != nil {
+				log.Debug("Hailo", "Could not write data for asset %d from outbox: %v", queued.Data.AssetId, err)
+				failed = append(failed, queued)
+				continue
+			}
+			_, err = conf.DeleteQueuedData(ctx, queued.Id)
+			if err != nil {
+				log.Error("Hailo", "Could not delete written data from outbox: %v", err)
+				return
+			}
+			written++
+		}
+		if written == 0 {
+			log.Debug("Hailo", "Could not write data from outbox, try again later")
+			return
+		}
+		log.Info("Hailo", "Written %d data from outbox", written)
+		for _, queued := range failed {
+			if !discardFailedData(ctx, queued) {
+				skipped++
+			}
+		}
+	}
+}
+
+// discardFailedData counts a failed attempt to write the queued data and discards the data after the maximum number
+// of attempts. Returns true, if the data is discarded.
+func discardFailedData(ctx context.Context, queued conf.QueuedData) bool {
+	attempts, err := conf.AddFailedAttempt(ctx, queued.Id)
+	if err != nil {
+		log.Error("Hailo", "Could not count failed attempt for data in outbox: %v", err)
+		return false
+	}
+	if attempts < maxOutboxAttempts {
+		return false
+	}
+	_, err = conf.DeleteQueuedData(ctx, queued.Id)
+	if err != nil {
+		log.Error("Hailo", "Could not delete failed data from outbox: %v", err)
+		return false
+	}
+	log.Error("Hailo", "Discarded data for asset %d and subtype %s from outbox after %d failed attempts: %v", queued.Data.AssetId, queued.Data.Subtype, attempts, queued.Data.Data)
+	return true
+}
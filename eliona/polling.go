@@ -0,0 +1,168 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"hailo/apiserver"
+	"hailo/hailo"
+	"sync"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+// pollingSchedule is the schedule of a device in adaptive polling mode
+type pollingSchedule struct {
+	interval time.Duration
+	next     time.Time
+	openings int
+}
+
+// pollingSchedules holds the schedule of each device polled since the start of the app
+var pollingSchedules sync.Map
+
+// polledSpecs holds the specifications last read for each configuration in adaptive polling mode
+var polledSpecs sync.Map
+
+// polledSpec are the specifications of a configuration with the time they were read
+type polledSpec struct {
+	specs  hailo.Specs
+	readAt time.Time
+}
+
+// PollingInterval returns the interval between two runs collecting data for the configuration. In adaptive polling
+// mode the runs are repeated with the minimum interval and only the devices due are polled in a run.
+func PollingInterval(config apiserver.Configuration) time.Duration {
+	if common.Val(config.AdaptivePolling) {
+		return time.Duration(config.MinIntervalSec) * time.Second
+	}
+	return time.Duration(config.IntervalSec) * time.Second
+}
+
+// PolledSpecs returns the specifications read for the configuration within the configured interval. In adaptive
+// polling mode the runs are repeated with the minimum interval, but the specifications are only read again from Hailo
+// FDS after the configured interval. Without adaptive polling the specifications are read in each run.
+func PolledSpecs(config apiserver.Configuration, now time.Time) (hailo.Specs, bool) {
+	if !common.Val(config.AdaptivePolling) {
+		return hailo.Specs{}, false
+	}
+	value, ok := polledSpecs.Load(common.Val(config.Id))
+	if !ok || now.Sub(value.(polledSpec).readAt) >= time.Duration(config.IntervalSec)*time.Second {
+		return hailo.Specs{}, false
+	}
+	return value.(polledSpec).specs, true
+}
+
+// RememberPolledSpecs remembers the specifications read for the configuration in adaptive polling mode
+func RememberPolledSpecs(config apiserver.Configuration, specs hailo.Specs, now time.Time) {
+	if !common.Val(config.AdaptivePolling) {
+		return
+	}
+	polledSpecs.Store(common.Val(config.Id), polledSpec{specs: specs, readAt: now})
+}
+
+// PollingDue returns true, if the status of the device has to be read in the current run. Without adaptive polling
+// and for devices not polled before, all devices are due.
+func PollingDue(config apiserver.Configuration, deviceId string, now time.Time) bool {
+	if !common.Val(config.AdaptivePolling) {
+		return true
+	}
+	schedule, ok := pollingSchedules.Load(registeredDeviceKey{common.Val(config.Id), deviceId})
+	return !ok || !now.Before(schedule.(pollingSchedule).next)
+}
+
+// SchedulePolling schedules the next poll of the device from the status read. Bins at or above the urgent fill level
+// and devices with an alarm are polled with the minimum interval. Devices without openings since the last poll are
+// idle and back off by doubling the interval up to the maximum interval. All other devices are polled with the
+// configured interval. For stations the fill levels and alarms of all components count.
+func SchedulePolling(config apiserver.Configuration, status hailo.Status, now time.Time) {
+	if !common.Val(config.AdaptivePolling) {
+		return
+	}
+	key := registeredDeviceKey{common.Val(config.Id), status.DeviceId}
+	interval, minInterval, maxInterval := pollingIntervals(config)
+	openings := status.DeviceTypeSpecific.InputCount + status.DeviceTypeSpecific.TotalInputsCount
+	if urgentStatus(status, config.UrgentFillLevel) {
+		interval = minInterval
+	} else if last, ok := pollingSchedules.Load(key); ok && last.(pollingSchedule).openings == openings {
+		interval = clampInterval(2*last.(pollingSchedule).interval, interval, maxInterval)
+	}
+	pollingSchedules.Store(key, pollingSchedule{
+		interval: interval,
+		next:     now.Add(interval),
+		openings: openings,
+	})
+}
+
+// SchedulePollingAfterError schedules the next poll of the device whose status could not be read. Like idle devices
+// the device backs off by doubling the interval up to the maximum interval, so unreachable devices are not polled in
+// each run. The openings of the last status read are kept.
+func SchedulePollingAfterError(config apiserver.Configuration, deviceId string, now time.Time) {
+	if !common.Val(config.AdaptivePolling) {
+		return
+	}
+	key := registeredDeviceKey{common.Val(config.Id), deviceId}
+	interval, _, maxInterval := pollingIntervals(config)
+	schedule := pollingSchedule{interval: interval}
+	if last, ok := pollingSchedules.Load(key); ok {
+		schedule.interval = clampInterval(2*last.(pollingSchedule).interval, interval, maxInterval)
+		schedule.openings = last.(pollingSchedule).openings
+	}
+	schedule.next = now.Add(schedule.interval)
+	pollingSchedules.Store(key, schedule)
+}
+
+// pollingIntervals returns the configured interval bounded by the minimum and maximum interval together with the
+// minimum and maximum interval
+func pollingIntervals(config apiserver.Configuration) (time.Duration, time.Duration, time.Duration) {
+	minInterval := time.Duration(config.MinIntervalSec) * time.Second
+	maxInterval := time.Duration(config.MaxIntervalSec) * time.Second
+	if maxInterval < minInterval {
+		maxInterval = minInterval
+	}
+	return clampInterval(time.Duration(config.IntervalSec)*time.Second, minInterval, maxInterval), minInterval, maxInterval
+}
+
+// urgentStatus returns true, if a fill level sensor of the device or of one of its components reports a level at or
+// above the urgent fill level in percent, or if an alarm is reported. Implausible fill levels are ignored.
+func urgentStatus(status hailo.Status, urgentFillLevel int32) bool {
+	specific := status.DeviceTypeSpecific
+	if specific.BinAlarm || common.Val(specific.FireAlarm) || common.Val(specific.TiltAlarm) {
+		return true
+	}
+	for _, reading := range specific.FillingLevel {
+		if reading.Level <= maxFillingLevel && float64(reading.Level)*100 >= float64(urgentFillLevel) {
+			return true
+		}
+	}
+	for _, compStatus := range specific.CompStatuses {
+		if urgentStatus(compStatus, urgentFillLevel) {
+			return true
+		}
+	}
+	return false
+}
+
+// clampInterval returns the interval bounded by the minimum and maximum interval
+func clampInterval(interval time.Duration, minInterval time.Duration, maxInterval time.Duration) time.Duration {
+	if interval < minInterval {
+		return minInterval
+	}
+	if interval > maxInterval {
+		return maxInterval
+	}
+	return interval
+}
@@ -0,0 +1,100 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"encoding/json"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"hailo/apiserver"
+	"hailo/hailo"
+	"testing"
+	"time"
+)
+
+func TestSchedulePolling(t *testing.T) {
+	config := apiserver.Configuration{
+		Id:              common.Ptr[int64](5001),
+		IntervalSec:     300,
+		AdaptivePolling: common.Ptr(true),
+		MinIntervalSec:  60,
+		MaxIntervalSec:  1200,
+		UrgentFillLevel: 80,
+	}
+	var normal, used, full, station hailo.Status
+	assert.Nil(t, json.Unmarshal([]byte(`{"device_id":"bin-1","device_type_specific":{"inputs_count":10,"filling_level":[{"level":0.5}]}}`), &normal))
+	assert.Nil(t, json.Unmarshal([]byte(`{"device_id":"bin-1","device_type_specific":{"inputs_count":12,"filling_level":[{"level":0.6}]}}`), &used))
+	assert.Nil(t, json.Unmarshal([]byte(`{"device_id":"bin-1","device_type_specific":{"inputs_count":12,"filling_level":[{"level":0.85}]}}`), &full))
+	assert.Nil(t, json.Unmarshal([]byte(`{"device_id":"station-1","device_type_specific":{"component_statuses":[`+
+		`{"device_id":"bin-3","device_type_specific":{"inputs_count":5,"filling_level":[{"level":0.2}]}},`+
+		`{"device_id":"bin-2","device_type_specific":{"inputs_count":3,"bin_alarm":true,"filling_level":[{"level":0.1}]}}]}}`), &station))
+	now := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Minute, PollingInterval(config))
+	assert.True(t, PollingDue(config, "bin-1", now))
+
+	// normal bin is polled with the configured interval
+	SchedulePolling(config, normal, now)
+	assert.False(t, PollingDue(config, "bin-1", now.Add(4*time.Minute)))
+	assert.True(t, PollingDue(config, "bin-1", now.Add(5*time.Minute)))
+
+	// idle bin backs off up to the maximum interval
+	SchedulePolling(config, normal, now)
+	assert.False(t, PollingDue(config, "bin-1", now.Add(9*time.Minute)))
+	assert.True(t, PollingDue(config, "bin-1", now.Add(10*time.Minute)))
+	SchedulePolling(config, normal, now)
+	SchedulePolling(config, normal, now)
+	assert.False(t, PollingDue(config, "bin-1", now.Add(19*time.Minute)))
+	assert.True(t, PollingDue(config, "bin-1", now.Add(20*time.Minute)))
+
+	// used bin is polled with the configured interval again
+	SchedulePolling(config, used, now)
+	assert.True(t, PollingDue(config, "bin-1", now.Add(5*time.Minute)))
+
+	// nearly full bin is polled with the minimum interval
+	SchedulePolling(config, full, now)
+	assert.True(t, PollingDue(config, "bin-1", now.Add(time.Minute)))
+
+	// station with an alarm of a component is polled with the minimum interval
+	SchedulePolling(config, station, now)
+	assert.True(t, PollingDue(config, "station-1", now.Add(time.Minute)))
+
+	// device failing to read backs off from the configured interval
+	SchedulePollingAfterError(config, "bin-4", now)
+	assert.False(t, PollingDue(config, "bin-4", now.Add(4*time.Minute)))
+	assert.True(t, PollingDue(config, "bin-4", now.Add(5*time.Minute)))
+	SchedulePollingAfterError(config, "bin-4", now)
+	assert.False(t, PollingDue(config, "bin-4", now.Add(9*time.Minute)))
+
+	// schedules of devices removed in FDS are dropped
+	ForgetRemovedDevices(config, []hailo.Spec{{DeviceId: "bin-1"}})
+	assert.False(t, PollingDue(config, "bin-1", now.Add(time.Second)))
+	assert.True(t, PollingDue(config, "bin-4", now))
+
+	// specs are read again after the configured interval
+	RememberPolledSpecs(config, hailo.Specs{Data: []hailo.Spec{{DeviceId: "bin-1"}}}, now)
+	specs, polled := PolledSpecs(config, now.Add(4*time.Minute))
+	assert.True(t, polled)
+	assert.Len(t, specs.Data, 1)
+	_, polled = PolledSpecs(config, now.Add(5*time.Minute))
+	assert.False(t, polled)
+
+	// without adaptive polling all devices are due
+	config.AdaptivePolling = nil
+	assert.Equal(t, 5*time.Minute, PollingInterval(config))
+	assert.True(t, PollingDue(config, "bin-1", now))
+	_, polled = PolledSpecs(config, now)
+	assert.False(t, polled)
+}
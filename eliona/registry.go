@@ -0,0 +1,112 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"context"
+	"hailo/apiserver"
+	"hailo/conf"
+	"hailo/hailo"
+	"sort"
+	"sync"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+// registeredDeviceKey identifies a Hailo device within a configuration
+type registeredDeviceKey struct {
+	configId int64
+	deviceId string
+}
+
+// registeredDevice holds the specification and the location of a device as seen during the last collection. For
+// containers of a station the device id of the station is the parent id.
+type registeredDevice struct {
+	spec     hailo.Spec
+	parentId string
+	position *hailo.Position
+}
+
+// registeredDevices holds the devices of all configurations processed since the start of the app
+var registeredDevices sync.Map
+
+// registerDevice remembers the device for analyses across all devices of a configuration
+func registerDevice(config apiserver.Configuration, spec hailo.Spec, parentId string, position *hailo.Position) {
+	registeredDevices.Store(registeredDeviceKey{common.Val(config.Id), spec.DeviceId}, registeredDevice{
+		spec:     spec,
+		parentId: parentId,
+		position: position,
+	})
+}
+
+// ForgetRemovedDevices removes the registered devices and the polling schedules of the configuration which are no
+// longer contained in the specifications read from Hailo FDS, e.g. devices deleted in FDS
+func ForgetRemovedDevices(config apiserver.Configuration, specs []hailo.Spec) {
+	deviceIds := make(map[string]bool)
+	for _, spec := range specs {
+		deviceIds[spec.DeviceId] = true
+		for _, subSpec := range spec.DeviceTypeSpecific.ComponentIdList {
+			deviceIds[subSpec.DeviceId] = true
+		}
+	}
+	forgetRemovedKeys(&registeredDevices, common.Val(config.Id), deviceIds)
+	forgetRemovedKeys(&pollingSchedules, common.Val(config.Id), deviceIds)
+}
+
+// forgetRemovedKeys deletes the entries of the configuration whose device is not contained in the device ids
+func forgetRemovedKeys(devices *sync.Map, configId int64, deviceIds map[string]bool) {
+	devices.Range(func(key, value any) bool {
+		deviceKey := key.(registeredDeviceKey)
+		if deviceKey.configId == configId && !deviceIds[deviceKey.deviceId] {
+			devices.Delete(key)
+		}
+		return true
+	})
+}
+
+// registeredDeviceOf returns the registered device of the configuration with the device id
+func registeredDeviceOf(config apiserver.Configuration, deviceId string) (registeredDevice, bool) {
+	value, found := registeredDevices.Load(registeredDeviceKey{common.Val(config.Id), deviceId})
+	if !found {
+		return registeredDevice{}, false
+	}
+	return value.(registeredDevice), true
+}
+
+// configDevices returns all registered devices of the configuration sorted by device id
+func configDevices(configId int64) []registeredDevice {
+	var devices []registeredDevice
+	registeredDevices.Range(func(key, value any) bool {
+		if key.(registeredDeviceKey).configId == configId {
+			devices = append(devices, value.(registeredDevice))
+		}
+		return true
+	})
+	sort.Slice(devices, func(i, j int) bool { return devices[i].spec.DeviceId < devices[j].spec.DeviceId })
+	return devices
+}
+
+// deviceAssetId returns the asset id mapped to the device in the first project of the configuration. The same data
+// is written to the assets of all projects. If no asset is mapped, nil is returned.
+func deviceAssetId(config apiserver.Configuration, deviceId string) *int32 {
+	for _, projectId := range conf.ProjIds(config) {
+		assetId, err := conf.GetAssetId(context.Background(), config, projectId, deviceId)
+		if err == nil && assetId != nil {
+			return assetId
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"hailo/apiserver"
+	"hailo/hailo"
+	"testing"
+)
+
+func TestForgetRemovedDevices(t *testing.T) {
+	config := apiserver.Configuration{Id: common.Ptr[int64](31)}
+	otherConfig := apiserver.Configuration{Id: common.Ptr[int64](32)}
+	registerDevice(config, hailo.Spec{DeviceId: "station"}, "", nil)
+	registerDevice(config, hailo.Spec{DeviceId: "bin"}, "station", nil)
+	registerDevice(config, hailo.Spec{DeviceId: "deleted"}, "", nil)
+	registerDevice(otherConfig, hailo.Spec{DeviceId: "deleted"}, "", nil)
+
+	station := hailo.Spec{DeviceId: "station"}
+	station.DeviceTypeSpecific.ComponentIdList = []hailo.Spec{{DeviceId: "bin"}}
+	ForgetRemovedDevices(config, []hailo.Spec{station})
+
+	assert.Len(t, configDevices(31), 2)
+	_, found := registeredDeviceOf(config, "deleted")
+	assert.False(t, found)
+	_, found = registeredDeviceOf(otherConfig, "deleted")
+	assert.True(t, found)
+}
@@ -0,0 +1,101 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"fmt"
+	"hailo/apiserver"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// DeviceError is a problem with a single device found during a collection run
+type DeviceError struct {
+	DeviceId string
+	Err      error
+}
+
+func (e DeviceError) Error() string {
+	return fmt.Sprintf("device %s: %v", e.DeviceId, e.Err)
+}
+
+// Report collects the problems with single devices during a collection run for a configuration. A problem with
+// one device does not stop the collection for other devices.
+type Report struct {
+	ConfigId int64
+	Started  time.Time
+
+	mutex   sync.Mutex
+	devices int
+	errors  []DeviceError
+}
+
+// NewReport starts a report for a collection run of the configuration
+func NewReport(config apiserver.Configuration) *Report {
+	return &Report{ConfigId: common.Val(config.Id), Started: time.Now()}
+}
+
+// AddDevice counts a processed device
+func (r *Report) AddDevice() {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.devices++
+}
+
+// AddError adds a problem with the device to the report
+func (r *Report) AddError(deviceId string, err error) {
+	if r == nil || err == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.errors = append(r.errors, DeviceError{DeviceId: deviceId, Err: err})
+}
+
+// Errors returns all problems added to the report
+func (r *Report) Errors() []DeviceError {
+	if r == nil {
+		return nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]DeviceError(nil), r.errors...)
+}
+
+// Log writes a summary of the collection run and all problems with devices to the log
+func (r *Report) Log() {
+	errors := r.Errors()
+	r.mutex.Lock()
+	devices := r.devices
+	r.mutex.Unlock()
+	duration := time.Since(r.Started).Round(time.Millisecond)
+	if len(errors) == 0 {
+		log.Info("Hailo", "Collecting %d processed %d devices in %v without problems", r.ConfigId, devices, duration)
+		return
+	}
+	var lines []string
+	for _, deviceError := range errors {
+		lines = append(lines, deviceError.Error())
+	}
+	log.Warn("Hailo", "Collecting %d processed %d devices in %v with %d problems:\n%s", r.ConfigId, devices, duration, len(errors), strings.Join(lines, "\n"))
+}
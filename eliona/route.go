@@ -0,0 +1,342 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"hailo/apiserver"
+	"hailo/conf"
+	"hailo/hailo"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+// Heuristics to order the bins of an emptying route
+const (
+	RouteOrderNearest = "nearest"
+	RouteOrderStation = "station"
+)
+
+// Reasons for a bin to be on an emptying route
+const (
+	routeReasonFull      = "full"
+	routeReasonPredicted = "predicted"
+)
+
+// DefaultRouteThreshold is the fill level in percent from which bins are emptied, if no threshold is requested
+const DefaultRouteThreshold = 80.0
+
+// overflowLevel is the expected fill level in percent from which a bin is predicted to overflow before the next
+// scheduled round
+const overflowLevel = 100.0
+
+// earthRadius is the mean radius of the earth in kilometers
+const earthRadius = 6371.0
+
+// EmptyingRoute selects the bins of the configuration with a fill level above the threshold or an expected fill
+// level above 100 % and orders them by the heuristic. The nearest-neighbour heuristic starts at the given position or
+// at the fullest bin. The station heuristic keeps the bins of a station together. Bins without location are added at
+// the end, the fullest first. The bins are read from the asset mappings, the fill levels and locations from the
+// current data in Eliona and the stations from the last readings, so the route is complete after a restart.
+func EmptyingRoute(config apiserver.Configuration, threshold float64, order string, start *hailo.Position) (apiserver.EmptyingRoute, error) {
+	assetMappings, err := conf.GetAssetMappings(context.Background(), common.Val(config.Id))
+	if err != nil {
+		return apiserver.EmptyingRoute{}, err
+	}
+	data, err := currentData(configAssetIds(config, assetMappings), binAssetTypes)
+	if err != nil {
+		return apiserver.EmptyingRoute{}, err
+	}
+	readings, err := conf.GetLastReadings(context.Background(), common.Val(config.Id), time.Now())
+	if err != nil {
+		return apiserver.EmptyingRoute{}, err
+	}
+	stations := make(map[string]string)
+	for _, reading := range readings {
+		stations[reading.DeviceId] = reading.StationId
+	}
+	return emptyingRoute(config, routeStops(config, assetMappings, data, stations), threshold, order, start)
+}
+
+// routeStops returns a stop for each bin of the configuration. The asset mapped in the first project of the
+// configuration is used, because the same data is written to the assets of all projects. Assets without current bin
+// data are no bins and skipped.
+func routeStops(config apiserver.Configuration, assetMappings []apiserver.AssetMapping, data map[int32]map[string]interface{}, stations map[string]string) []apiserver.EmptyingStop {
+	var stops []apiserver.EmptyingStop
+	known := make(map[string]bool)
+	for _, projectId := range conf.ProjIds(config) {
+		for _, assetMapping := range assetMappings {
+			assetData, found := data[assetMapping.AssetId]
+			if assetMapping.ProjId != projectId || known[assetMapping.DeviceId] || !found {
+				continue
+			}
+			known[assetMapping.DeviceId] = true
+			stop := apiserver.EmptyingStop{DeviceId: assetMapping.DeviceId, AssetId: common.Ptr(assetMapping.AssetId)}
+			if level, ok := dataNumber(assetData["volumepercent"]); ok {
+				stop.Volumepercent = &level
+			}
+			if expected, ok := dataNumber(assetData["exp_percent"]); ok {
+				stop.ExpPercent = &expected
+			}
+			if stationId := stations[assetMapping.DeviceId]; stationId != "" {
+				stop.StationId = common.Ptr(stationId)
+			}
+			latitude, latitudeOk := dataNumber(assetData["latitude"])
+			longitude, longitudeOk := dataNumber(assetData["longitude"])
+			if assetMapping.Latitude != nil && assetMapping.Longitude != nil {
+				stop.Latitude, stop.Longitude = assetMapping.Latitude, assetMapping.Longitude
+			} else if latitudeOk && longitudeOk {
+				stop.Latitude, stop.Longitude = &latitude, &longitude
+			}
+			if assetMapping.Address != nil {
+				stop.Address = assetMapping.Address
+			} else if address, ok := assetData["address"].(string); ok && address != "" {
+				stop.Address = common.Ptr(address)
+			}
+			stops = append(stops, stop)
+		}
+	}
+	sort.Slice(stops, func(i, j int) bool { return stops[i].DeviceId < stops[j].DeviceId })
+	return stops
+}
+
+// emptyingRoute selects the stops to be emptied and orders them by the heuristic
+func emptyingRoute(config apiserver.Configuration, stops []apiserver.EmptyingStop, threshold float64, order string, start *hailo.Position) (apiserver.EmptyingRoute, error) {
+	if threshold <= 0 {
+		threshold = DefaultRouteThreshold
+	}
+	if order == "" {
+		order = RouteOrderNearest
+	}
+	if order != RouteOrderNearest && order != RouteOrderStation {
+		return apiserver.EmptyingRoute{}, fmt.Errorf("unknown route order %s", order)
+	}
+
+	var groups [][]apiserver.EmptyingStop
+	groupIndex := make(map[string]int)
+	for _, stop := range stops {
+		switch {
+		case stop.Volumepercent != nil && *stop.Volumepercent >= threshold:
+			stop.Reason = routeReasonFull
+		case stop.ExpPercent != nil && *stop.ExpPercent >= overflowLevel:
+			stop.Reason = routeReasonPredicted
+		default:
+			continue
+		}
+		group := stop.DeviceId
+		if order == RouteOrderStation && stop.StationId != nil {
+			group = *stop.StationId
+		}
+		if index, found := groupIndex[group]; found {
+			groups[index] = append(groups[index], stop)
+			continue
+		}
+		groupIndex[group] = len(groups)
+		groups = append(groups, []apiserver.EmptyingStop{stop})
+	}
+
+	route := apiserver.EmptyingRoute{
+		ConfigId:  common.Val(config.Id),
+		Order:     order,
+		Threshold: threshold,
+		Stops:     []apiserver.EmptyingStop{},
+	}
+	for _, group := range orderNearest(groups, start) {
+		route.Stops = append(route.Stops, group...)
+	}
+	for i := range route.Stops {
+		route.Stops[i].Sequence = int32(i + 1)
+	}
+	route.Distance = math.Round(routeDistance(route.Stops, start)*1000) / 1000
+	return route, nil
+}
+
+// orderNearest orders the groups of stops by the nearest-neighbour heuristic using the first located stop of each
+// group. Groups without location are added at the end, the fullest first.
+func orderNearest(groups [][]apiserver.EmptyingStop, start *hailo.Position) [][]apiserver.EmptyingStop {
+	var located, unlocated [][]apiserver.EmptyingStop
+	for _, group := range groups {
+		if groupLocation(group) != nil {
+			located = append(located, group)
+		} else {
+			unlocated = append(unlocated, group)
+		}
+	}
+	sort.SliceStable(located, func(i, j int) bool { return groupLevel(located[i]) > groupLevel(located[j]) })
+	sort.SliceStable(unlocated, func(i, j int) bool { return groupLevel(unlocated[i]) > groupLevel(unlocated[j]) })
+
+	var ordered [][]apiserver.EmptyingStop
+	current := start
+	if current == nil || current.Latitude == nil || current.Longitude == nil {
+		current = nil
+	}
+	for len(located) > 0 {
+		next := 0
+		if current != nil {
+			for i := range located {
+				if distance(current, groupLocation(located[i])) < distance(current, groupLocation(located[next])) {
+					next = i
+				}
+			}
+		}
+		ordered = append(ordered, located[next])
+		current = groupLocation(located[next])
+		located = append(located[:next], located[next+1:]...)
+	}
+	return append(ordered, unlocated...)
+}
+
+// groupLocation returns the location of the first located stop of the group or nil
+func groupLocation(group []apiserver.EmptyingStop) *hailo.Position {
+	for _, stop := range group {
+		if stop.Latitude != nil && stop.Longitude != nil {
+			return &hailo.Position{Latitude: stop.Latitude, Longitude: stop.Longitude}
+		}
+	}
+	return nil
+}
+
+// groupLevel returns the highest fill level of the stops of the group
+func groupLevel(group []apiserver.EmptyingStop) float64 {
+	level := math.Inf(-1)
+	for _, stop := range group {
+		if stop.Volumepercent != nil && *stop.Volumepercent > level {
+			level = *stop.Volumepercent
+		}
+	}
+	return level
+}
+
+// routeDistance returns the distance in kilometers between the located stops along the route
+func routeDistance(stops []apiserver.EmptyingStop, start *hailo.Position) float64 {
+	var total float64
+	previous := start
+	if previous != nil && (previous.Latitude == nil || previous.Longitude == nil) {
+		previous = nil
+	}
+	for _, stop := range stops {
+		location := groupLocation([]apiserver.EmptyingStop{stop})
+		if location == nil {
+			continue
+		}
+		if previous != nil {
+			total += distance(previous, location)
+		}
+		previous = location
+	}
+	return total
+}
+
+// distance returns the great-circle distance in kilometers between the positions
+func distance(a *hailo.Position, b *hailo.Position) float64 {
+	lat1, lat2 := *a.Latitude*math.Pi/180, *b.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (*b.Longitude - *a.Longitude) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+// EmptyingRouteCsv returns the stops of the route as CSV with a header line
+func EmptyingRouteCsv(route apiserver.EmptyingRoute) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	err := writer.Write([]string{"sequence", "deviceId", "stationId", "assetId", "latitude", "longitude", "address", "volumepercent", "expPercent", "reason"})
+	if err != nil {
+		return nil, err
+	}
+	for _, stop := range route.Stops {
+		err = writer.Write([]string{
+			strconv.Itoa(int(stop.Sequence)),
+			stop.DeviceId,
+			common.Val(stop.StationId),
+			csvValue(stop.AssetId),
+			csvValue(stop.Latitude),
+			csvValue(stop.Longitude),
+			common.Val(stop.Address),
+			csvValue(stop.Volumepercent),
+			csvValue(stop.ExpPercent),
+			stop.Reason,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
+
+// csvValue formats the value for CSV. Missing values are empty.
+func csvValue[T int32 | float64](value *T) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(*value)
+}
+
+// gpx is a GPX 1.1 document with one route
+type gpx struct {
+	XMLName xml.Name `xml:"gpx"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Version string   `xml:"version,attr"`
+	Creator string   `xml:"creator,attr"`
+	Route   gpxRoute `xml:"rte"`
+}
+
+type gpxRoute struct {
+	Name   string          `xml:"name"`
+	Points []gpxRoutePoint `xml:"rtept"`
+}
+
+type gpxRoutePoint struct {
+	Latitude    float64 `xml:"lat,attr"`
+	Longitude   float64 `xml:"lon,attr"`
+	Name        string  `xml:"name"`
+	Description string  `xml:"desc,omitempty"`
+}
+
+// EmptyingRouteGpx returns the located stops of the route as GPX route, e.g. for navigation devices
+func EmptyingRouteGpx(route apiserver.EmptyingRoute) ([]byte, error) {
+	document := gpx{
+		Xmlns:   "http://www.topografix.com/GPX/1/1",
+		Version: "1.1",
+		Creator: "Hailo app",
+		Route:   gpxRoute{Name: fmt.Sprintf("Emptying route config %d", route.ConfigId)},
+	}
+	for _, stop := range route.Stops {
+		if stop.Latitude == nil || stop.Longitude == nil {
+			continue
+		}
+		document.Route.Points = append(document.Route.Points, gpxRoutePoint{
+			Latitude:    *stop.Latitude,
+			Longitude:   *stop.Longitude,
+			Name:        fmt.Sprintf("%d %s", stop.Sequence, stop.DeviceId),
+			Description: common.Val(stop.Address),
+		})
+	}
+	content, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), content...), nil
+}
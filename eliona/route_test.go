@@ -0,0 +1,98 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"hailo/apiserver"
+	"hailo/hailo"
+	"strings"
+	"testing"
+)
+
+func TestOrderNearest(t *testing.T) {
+	groups := [][]apiserver.EmptyingStop{
+		{{DeviceId: "far", Volumepercent: common.Ptr(90.0), Latitude: common.Ptr(47.5), Longitude: common.Ptr(8.5)}},
+		{{DeviceId: "near", Volumepercent: common.Ptr(85.0), Latitude: common.Ptr(47.37), Longitude: common.Ptr(8.54)}},
+		{{DeviceId: "middle", Volumepercent: common.Ptr(99.0), Latitude: common.Ptr(47.4), Longitude: common.Ptr(8.54)}},
+		{{DeviceId: "unlocated", Volumepercent: common.Ptr(100.0)}},
+	}
+
+	var order []string
+	for _, group := range orderNearest(groups, &hailo.Position{Latitude: common.Ptr(47.36), Longitude: common.Ptr(8.54)}) {
+		order = append(order, group[0].DeviceId)
+	}
+	assert.Equal(t, []string{"near", "middle", "far", "unlocated"}, order)
+
+	order = nil
+	for _, group := range orderNearest(groups, nil) {
+		order = append(order, group[0].DeviceId)
+	}
+	assert.Equal(t, []string{"middle", "near", "far", "unlocated"}, order)
+}
+
+func TestEmptyingRoute(t *testing.T) {
+	config := apiserver.Configuration{Id: common.Ptr[int64](1), ProjIds: &[]string{"99", "98"}}
+	assetMappings := []apiserver.AssetMapping{
+		{ConfigId: 1, ProjId: "98", DeviceId: "bin-1", AssetId: 4701},
+		{ConfigId: 1, ProjId: "99", DeviceId: "bin-1", AssetId: 4711},
+		{ConfigId: 1, ProjId: "99", DeviceId: "bin-2", AssetId: 4712, Latitude: common.Ptr(47.4), Longitude: common.Ptr(8.54)},
+		{ConfigId: 1, ProjId: "99", DeviceId: "bin-3", AssetId: 4713},
+		{ConfigId: 1, ProjId: "99", DeviceId: "bin-4", AssetId: 4714},
+		{ConfigId: 1, ProjId: "99", DeviceId: "station", AssetId: 4710},
+	}
+	data := map[int32]map[string]interface{}{
+		4701: {"volumepercent": 95.0},
+		4711: {"volumepercent": 95.0, "latitude": 47.37, "longitude": 8.54, "address": "Bahnhofstrasse 1"},
+		4712: {"volumepercent": 85.0, "latitude": 47.5, "longitude": 8.5},
+		4713: {"volumepercent": 20.0, "exp_percent": 120.0},
+		4714: {"volumepercent": 20.0},
+	}
+	stops := routeStops(config, assetMappings, data, map[string]string{"bin-1": "station", "bin-3": "station"})
+	assert.Len(t, stops, 4)
+	assert.Equal(t, int32(4711), *stops[0].AssetId)
+	assert.Equal(t, "station", *stops[0].StationId)
+	assert.Equal(t, "Bahnhofstrasse 1", *stops[0].Address)
+	assert.Equal(t, 47.4, *stops[1].Latitude)
+
+	route, err := emptyingRoute(config, stops, 0, RouteOrderStation, &hailo.Position{Latitude: common.Ptr(47.41), Longitude: common.Ptr(8.54)})
+	assert.Nil(t, err)
+	var order []string
+	for _, stop := range route.Stops {
+		order = append(order, stop.DeviceId+":"+stop.Reason)
+	}
+	assert.Equal(t, []string{"bin-2:full", "bin-1:full", "bin-3:predicted"}, order)
+	assert.Equal(t, int32(3), route.Stops[2].Sequence)
+}
+
+func TestEmptyingRouteFormats(t *testing.T) {
+	route := apiserver.EmptyingRoute{ConfigId: 1, Stops: []apiserver.EmptyingStop{
+		{Sequence: 1, DeviceId: "bin", StationId: common.Ptr("station"), AssetId: common.Ptr[int32](4711), Latitude: common.Ptr(47.37), Longitude: common.Ptr(8.54), Volumepercent: common.Ptr(92.0), Reason: routeReasonFull},
+		{Sequence: 2, DeviceId: "unlocated", ExpPercent: common.Ptr(110.0), Reason: routeReasonPredicted},
+	}}
+	content, err := EmptyingRouteCsv(route)
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "1,bin,station,4711,47.37,8.54,,92,,full", lines[1])
+	assert.Equal(t, "2,unlocated,,,,,,,110,predicted", lines[2])
+
+	content, err = EmptyingRouteGpx(route)
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), `<rtept lat="47.37" lon="8.54">`)
+	assert.NotContains(t, string(content), "unlocated")
+}
@@ -0,0 +1,181 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"hailo/apiserver"
+	"sort"
+	"strings"
+	"sync"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+//go:embed asset-type-*.json
+var assetTypeFiles embed.FS
+
+// appValues lists the values computed by the app for each asset type, which can be mapped with the source app
+var appValues = map[string][]string{
+	BinAssetType:              {"volume", "latitude", "longitude", "address", "active", "volumepercent", "volumepercent_avg", "level_sensors", "sensor_errors", "sensor_fault", "bat_days_left"},
+	RecyclingStationAssetType: {"volume", "latitude", "longitude", "address", "active", "alarm", "sensor_errors", "bat_days_left"},
+}
+
+// assetTypeAttributes holds the subtype of each attribute by asset type name read from the asset type files
+var assetTypeAttributes struct {
+	once       sync.Once
+	attributes map[string]map[string]api.DataSubtype
+	err        error
+}
+
+// CheckAttributeMappings logs all default attribute mappings which do not match the asset types and adds the
+// attributes declared by the mappings to the asset types. Attributes defined in an asset type but never written are
+// logged as warning.
+func CheckAttributeMappings() {
+	mismatches, unwritten, err := validateAttributeMappings(defaultAttributeMappings)
+	if err != nil {
+		log.Error("Hailo", "Could not check attribute mappings against asset types: %v", err)
+		return
+	}
+	for _, mismatch := range mismatches {
+		log.Error("Hailo", "Attribute mapping does not match asset type: %v", mismatch)
+	}
+	err = upsertDeclaredAttributes(defaultAttributeMappings)
+	if err != nil {
+		log.Error("Hailo", "Could not add mapped attributes to asset types: %v", err)
+	}
+	if len(unwritten) > 0 {
+		log.Warn("Hailo", "Attributes defined in asset types but never written: %s", strings.Join(unwritten, ", "))
+	}
+}
+
+// validateAttributeMappings checks the attribute mappings against the asset types. It returns the mismatches and
+// the attributes which are not written by any mapping as "asset type: attribute".
+func validateAttributeMappings(mappings []apiserver.AttributeMapping) ([]error, []string, error) {
+	attributes, err := readAssetTypeAttributes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var mismatches []error
+	written := make(map[string]bool)
+	for _, attributeMapping := range mappings {
+		err := validateAttributeMapping(attributeMapping)
+		if err != nil {
+			mismatches = append(mismatches, fmt.Errorf("%s: %s: %v", attributeMapping.AssetType, attributeMapping.Attribute, err))
+			continue
+		}
+		written[attributeMapping.AssetType+": "+attributeMapping.Attribute] = true
+	}
+
+	var unwritten []string
+	for assetType, subtypes := range attributes {
+		for attribute := range subtypes {
+			if !written[assetType+": "+attribute] {
+				unwritten = append(unwritten, assetType+": "+attribute)
+			}
+		}
+	}
+	sort.Strings(unwritten)
+	return mismatches, unwritten, nil
+}
+
+// validateAttributeMapping checks that the mapped attribute is defined with the same subtype in the asset type or
+// declared with an attribute type by the mapping and the source of the value is known
+func validateAttributeMapping(attributeMapping apiserver.AttributeMapping) error {
+	attributes, err := readAssetTypeAttributes()
+	if err != nil {
+		return err
+	}
+	subtypes, ok := attributes[attributeMapping.AssetType]
+	if !ok {
+		return fmt.Errorf("asset type '%s' is not defined", attributeMapping.AssetType)
+	}
+	subtype, ok := subtypes[attributeMapping.Attribute]
+	if !ok && attributeMapping.AttributeType == nil {
+		return fmt.Errorf("attribute is not defined, declare it with an attribute type")
+	}
+	if !ok {
+		switch api.DataSubtype(attributeMapping.Subtype) {
+		case api.SUBTYPE_INPUT, api.SUBTYPE_INFO, api.SUBTYPE_STATUS:
+			subtype = api.DataSubtype(attributeMapping.Subtype)
+		default:
+			return fmt.Errorf("unknown subtype '%s'", attributeMapping.Subtype)
+		}
+	}
+	if string(subtype) != attributeMapping.Subtype {
+		return fmt.Errorf("attribute is mapped as %s but defined as %s", attributeMapping.Subtype, subtype)
+	}
+	switch attributeMapping.Source {
+	case sourceSpec, sourceStatus, sourceDiag:
+	case sourceApp:
+		if !contains(appValues[attributeMapping.AssetType], attributeMapping.Path) {
+			return fmt.Errorf("app value '%s' is not computed", attributeMapping.Path)
+		}
+	default:
+		return fmt.Errorf("unknown source '%s'", attributeMapping.Source)
+	}
+	if attributeMapping.Path == "" {
+		return fmt.Errorf("path is empty")
+	}
+	if attributeMapping.Conversion != nil && *attributeMapping.Conversion != conversionHours && *attributeMapping.Conversion != conversionDays {
+		return fmt.Errorf("unknown conversion '%s'", *attributeMapping.Conversion)
+	}
+	return nil
+}
+
+// readAssetTypeAttributes reads the subtype of each attribute defined in the asset type files by asset type name
+func readAssetTypeAttributes() (map[string]map[string]api.DataSubtype, error) {
+	assetTypeAttributes.once.Do(func() {
+		assetTypeAttributes.attributes = make(map[string]map[string]api.DataSubtype)
+		files, err := assetTypeFiles.ReadDir(".")
+		if err != nil {
+			assetTypeAttributes.err = err
+			return
+		}
+		for _, file := range files {
+			content, err := assetTypeFiles.ReadFile(file.Name())
+			if err != nil {
+				assetTypeAttributes.err = err
+				return
+			}
+			var assetType api.AssetType
+			err = json.Unmarshal(content, &assetType)
+			if err != nil {
+				assetTypeAttributes.err = fmt.Errorf("unmarshaling %s: %v", file.Name(), err)
+				return
+			}
+			subtypes := make(map[string]api.DataSubtype)
+			for _, attribute := range assetType.Attributes {
+				subtypes[attribute.Name] = attribute.Subtype
+			}
+			assetTypeAttributes.attributes[assetType.Name] = subtypes
+		}
+	})
+	return assetTypeAttributes.attributes, assetTypeAttributes.err
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAttributeMappings(t *testing.T) {
+	mappings, err := readDefaultAttributeMappings("")
+	assert.Nil(t, err)
+	mismatches, unwritten, err := validateAttributeMappings(mappings)
+	assert.Nil(t, err)
+	assert.Empty(t, mismatches)
+
+	// The values reported by hubs are written by UpsertDataForHub. Only the attributes of the Digital Hub asset type
+	// which hubs don't report are never written.
+	assert.NotContains(t, unwritten, DigitalHubAssetType+": rssi")
+	assert.NotContains(t, unwritten, DigitalHubAssetType+": firmware_version")
+	assert.Equal(t, []string{
+		DigitalHubAssetType + ": closed",
+		DigitalHubAssetType + ": lastclean",
+		DigitalHubAssetType + ": percent",
+		DigitalHubAssetType + ": time",
+		DigitalHubAssetType + ": totalopenings",
+		DigitalHubAssetType + ": voltage",
+		DigitalHubAssetType + ": volume",
+		DigitalHubAssetType + ": volumepercent",
+	}, unwritten)
+}
@@ -0,0 +1,185 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"context"
+	"hailo/apiserver"
+	"hailo/conf"
+	"strings"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/client"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+// maxHierarchyDepth limits the walk up the locational hierarchy, so that cyclic hierarchies cannot block requests
+const maxHierarchyDepth = 32
+
+// DashboardScope restricts the assets shown on a dashboard generated from a template. Zero values do not restrict
+// the assets.
+type DashboardScope struct {
+	StationAssetId  int32
+	HubAssetId      int32
+	ContentCategory string
+	ConfigId        int64
+}
+
+// scopeFilter selects the assets found by the queries of a template which are within the scope
+type scopeFilter struct {
+	scope          DashboardScope
+	configAssetIds map[int32]bool
+	hubAssetIds    map[int32]bool
+	parents        map[int32]*int32
+}
+
+// newScopeFilter creates the filter for the scope. For a configuration or a hub the mapped asset ids are read once.
+func newScopeFilter(ctx context.Context, scope DashboardScope) (*scopeFilter, error) {
+	filter := &scopeFilter{scope: scope, parents: make(map[int32]*int32)}
+	if scope.HubAssetId > 0 {
+		assetMappings, err := conf.GetAssetMappings(ctx, 0)
+		if err != nil {
+			return nil, err
+		}
+		filter.hubAssetIds = hubAssetIds(assetMappings, scope.HubAssetId)
+	}
+	if scope.ConfigId > 0 {
+		assetMappings, err := conf.GetAssetMappings(ctx, scope.ConfigId)
+		if err != nil {
+			return nil, err
+		}
+		filter.configAssetIds = make(map[int32]bool)
+		for _, assetMapping := range assetMappings {
+			filter.configAssetIds[assetMapping.AssetId] = true
+		}
+	}
+	return filter, nil
+}
+
+// hubAssetIds returns the ids of the assets mapped by the configuration of the hub asset in the project of the hub.
+// Assets of devices are created without locational parent, so the hub is resolved by its configuration instead of
+// the locational hierarchy. If the hub asset is not mapped, no asset ids are returned.
+func hubAssetIds(assetMappings []apiserver.AssetMapping, hubAssetId int32) map[int32]bool {
+	assetIds := make(map[int32]bool)
+	for _, hubMapping := range assetMappings {
+		if hubMapping.AssetId != hubAssetId {
+			continue
+		}
+		for _, assetMapping := range assetMappings {
+			if assetMapping.ConfigId == hubMapping.ConfigId && assetMapping.ProjId == hubMapping.ProjId {
+				assetIds[assetMapping.AssetId] = true
+			}
+		}
+	}
+	return assetIds
+}
+
+// needsChildren returns true, if the children of assets are necessary to filter the assets. Stations are in the
+// scope of a content category, if they contain bins of the category.
+func (filter *scopeFilter) needsChildren() bool {
+	return filter.scope.ContentCategory != ""
+}
+
+// apply returns the assets within the scope. The children of the assets are filtered in the same way.
+func (filter *scopeFilter) apply(assets []api.Asset) ([]api.Asset, error) {
+	for _, asset := range assets {
+		filter.parents[asset.GetId()] = asset.ParentLocationalAssetId.Get()
+		for _, child := range asset.ChildrenInfo {
+			filter.parents[child.GetId()] = common.Ptr(asset.GetId())
+		}
+	}
+	var filtered []api.Asset
+	for _, asset := range assets {
+		within, err := filter.within(asset)
+		if err != nil {
+			return nil, err
+		}
+		if !within {
+			continue
+		}
+		var children []api.Asset
+		for _, child := range asset.ChildrenInfo {
+			within, err := filter.within(child)
+			if err != nil {
+				return nil, err
+			}
+			if within && filter.inCategory(child) {
+				children = append(children, child)
+			}
+		}
+		if !filter.inCategory(asset) && len(children) == 0 {
+			continue
+		}
+		asset.ChildrenInfo = children
+		filtered = append(filtered, asset)
+	}
+	return filtered, nil
+}
+
+// within returns true, if the asset belongs to the configuration and the hub and is located below the station
+func (filter *scopeFilter) within(asset api.Asset) (bool, error) {
+	if filter.configAssetIds != nil && !filter.configAssetIds[asset.GetId()] {
+		return false, nil
+	}
+	if filter.hubAssetIds != nil && !filter.hubAssetIds[asset.GetId()] {
+		return false, nil
+	}
+	if filter.scope.StationAssetId == 0 {
+		return true, nil
+	}
+	return filter.below(asset.GetId(), filter.scope.StationAssetId)
+}
+
+// below returns true, if the asset is the ancestor itself or located below the ancestor. Parents of assets not found
+// by the queries are read from Eliona.
+func (filter *scopeFilter) below(assetId int32, ancestorId int32) (bool, error) {
+	for depth := 0; depth < maxHierarchyDepth; depth++ {
+		if assetId == ancestorId {
+			return true, nil
+		}
+		parentId, known := filter.parents[assetId]
+		if !known {
+			asset, _, err := client.NewClient().AssetsAPI.
+				GetAssetById(client.AuthenticationContext(), assetId).
+				Execute()
+			if err != nil {
+				return false, err
+			}
+			parentId = asset.ParentLocationalAssetId.Get()
+			filter.parents[assetId] = parentId
+		}
+		if parentId == nil {
+			return false, nil
+		}
+		assetId = *parentId
+	}
+	return false, nil
+}
+
+// inCategory returns true, if the asset is tagged with the content category or has the asset type of the category
+func (filter *scopeFilter) inCategory(asset api.Asset) bool {
+	category := strings.ToLower(strings.TrimSpace(filter.scope.ContentCategory))
+	if category == "" {
+		return true
+	}
+	for _, tag := range asset.Tags {
+		if strings.ToLower(strings.TrimSpace(tag)) == category {
+			return true
+		}
+	}
+	contentCategory, ok := contentCategories[category]
+	return ok && asset.AssetType == contentCategory.assetType
+}
@@ -0,0 +1,70 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"hailo/apiserver"
+	"testing"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+)
+
+func TestScopeFilter(t *testing.T) {
+	paperBin := api.Asset{Id: *api.NewNullableInt32(common.Ptr[int32](11)), ParentLocationalAssetId: *api.NewNullableInt32(common.Ptr[int32](10)), AssetType: BinAssetType, Tags: []string{"Paper"}}
+	petBin := api.Asset{Id: *api.NewNullableInt32(common.Ptr[int32](12)), ParentLocationalAssetId: *api.NewNullableInt32(common.Ptr[int32](10)), AssetType: BinAssetType, Tags: []string{"PET"}}
+	station := api.Asset{Id: *api.NewNullableInt32(common.Ptr[int32](10)), AssetType: RecyclingStationAssetType, ChildrenInfo: []api.Asset{paperBin, petBin}}
+	otherBin := api.Asset{Id: *api.NewNullableInt32(common.Ptr[int32](20)), AssetType: BinAssetType, Tags: []string{"paper"}}
+	assets := []api.Asset{station, paperBin, petBin, otherBin}
+
+	filter := &scopeFilter{scope: DashboardScope{}, parents: make(map[int32]*int32)}
+	filtered, err := filter.apply(assets)
+	assert.Nil(t, err)
+	assert.Len(t, filtered, 4)
+
+	filter = &scopeFilter{scope: DashboardScope{StationAssetId: 10, ContentCategory: "paper"}, parents: make(map[int32]*int32)}
+	filtered, err = filter.apply(assets)
+	assert.Nil(t, err)
+	assert.Len(t, filtered, 2)
+	assert.Equal(t, int32(10), filtered[0].GetId())
+	assert.Equal(t, []api.Asset{paperBin}, filtered[0].ChildrenInfo)
+	assert.Equal(t, int32(11), filtered[1].GetId())
+
+	filter = &scopeFilter{scope: DashboardScope{ConfigId: 1}, configAssetIds: map[int32]bool{20: true}, parents: make(map[int32]*int32)}
+	filtered, err = filter.apply(assets)
+	assert.Nil(t, err)
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, int32(20), filtered[0].GetId())
+
+	filter = &scopeFilter{scope: DashboardScope{HubAssetId: 1}, hubAssetIds: map[int32]bool{1: true, 10: true, 11: true}, parents: make(map[int32]*int32)}
+	filtered, err = filter.apply(assets)
+	assert.Nil(t, err)
+	assert.Len(t, filtered, 2)
+	assert.Equal(t, []api.Asset{paperBin}, filtered[0].ChildrenInfo)
+	assert.Equal(t, int32(11), filtered[1].GetId())
+}
+
+func TestHubAssetIds(t *testing.T) {
+	assetMappings := []apiserver.AssetMapping{
+		{ConfigId: 1, ProjId: "99", DeviceId: "hub", AssetId: 1},
+		{ConfigId: 1, ProjId: "99", DeviceId: "bin", AssetId: 2},
+		{ConfigId: 1, ProjId: "98", DeviceId: "bin", AssetId: 3},
+		{ConfigId: 2, ProjId: "99", DeviceId: "bin", AssetId: 4},
+	}
+	assert.Equal(t, map[int32]bool{1: true, 2: true}, hubAssetIds(assetMappings, 1))
+	assert.Empty(t, hubAssetIds(assetMappings, 5))
+}
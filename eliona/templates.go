@@ -0,0 +1,317 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+//go:generate go run gen_metadata.go
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/client"
+	"gopkg.in/yaml.v3"
+)
+
+// dashboardTemplateFiles contains the dashboard templates as JSON or YAML files
+//
+//go:embed dashboards
+var dashboardTemplateFiles embed.FS
+
+// dashboardTemplate defines a dashboard. Each widget template is repeated for all assets found by its asset query.
+type dashboardTemplate struct {
+	Name    string           `json:"name" yaml:"name"`
+	Widgets []widgetTemplate `json:"widgets" yaml:"widgets"`
+}
+
+// widgetTemplate defines a widget with placeholders like {{asset.id}}. Without asset query the widget is added once.
+// The child data is added to the widget for each child asset, e.g. for each bin of a station.
+type widgetTemplate struct {
+	Assets    *assetQuery              `json:"assets" yaml:"assets"`
+	Widget    map[string]interface{}   `json:"widget" yaml:"widget"`
+	ChildData []map[string]interface{} `json:"childData" yaml:"childData"`
+}
+
+// assetQuery selects the assets of a project either by the kinds of device or by asset type names. The assets can be
+// sorted, otherwise they are in the order returned by Eliona.
+type assetQuery struct {
+	Kind       string   `json:"kind" yaml:"kind"`
+	Kinds      []string `json:"kinds" yaml:"kinds"`
+	AssetTypes []string `json:"assetTypes" yaml:"assetTypes"`
+	Children   bool     `json:"children" yaml:"children"`
+	Sort       string   `json:"sort" yaml:"sort"`
+}
+
+// Sort orders of asset queries
+const (
+	sortHealth = "health"
+)
+
+// dashboardTemplates holds all templates read from the template files by name
+var dashboardTemplates struct {
+	once      sync.Once
+	templates map[string]dashboardTemplate
+	err       error
+}
+
+// placeholderPattern matches placeholders like {{asset.id}}
+var placeholderPattern = regexp.MustCompile(`{{\s*([a-zA-Z.]+)\s*}}`)
+
+// readDashboardTemplates reads all template files once
+func readDashboardTemplates() (map[string]dashboardTemplate, error) {
+	dashboardTemplates.once.Do(func() {
+		dashboardTemplates.templates = make(map[string]dashboardTemplate)
+		files, err := dashboardTemplateFiles.ReadDir("dashboards")
+		if err != nil {
+			dashboardTemplates.err = err
+			return
+		}
+		for _, file := range files {
+			template, err := readDashboardTemplate(path.Join("dashboards", file.Name()))
+			if err != nil {
+				dashboardTemplates.err = fmt.Errorf("reading dashboard template %s: %w", file.Name(), err)
+				return
+			}
+			dashboardTemplates.templates[template.Name] = template
+		}
+	})
+	return dashboardTemplates.templates, dashboardTemplates.err
+}
+
+// readDashboardTemplate reads a template from a JSON or YAML file
+func readDashboardTemplate(name string) (dashboardTemplate, error) {
+	var template dashboardTemplate
+	content, err := dashboardTemplateFiles.ReadFile(name)
+	if err != nil {
+		return template, err
+	}
+	switch path.Ext(name) {
+	case ".json":
+		err = json.Unmarshal(content, &template)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &template)
+	default:
+		err = fmt.Errorf("unknown file type")
+	}
+	if err == nil && template.Name == "" {
+		err = fmt.Errorf("template without name")
+	}
+	return template, err
+}
+
+// DashboardTemplateNames returns the names of all dashboard templates
+func DashboardTemplateNames() ([]string, error) {
+	templates, err := readDashboardTemplates()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// DashboardFromTemplate builds the dashboard for the project from the template with the given name. Only assets
+// within the scope are shown. If no template exists with the name, false is returned.
+func DashboardFromTemplate(ctx context.Context, name string, projectId string, scope DashboardScope) (api.Dashboard, bool, error) {
+	templates, err := readDashboardTemplates()
+	if err != nil {
+		return api.Dashboard{}, false, err
+	}
+	template, ok := templates[name]
+	if !ok {
+		return api.Dashboard{}, false, nil
+	}
+	filter, err := newScopeFilter(ctx, scope)
+	if err != nil {
+		return api.Dashboard{}, true, err
+	}
+	dashboard := api.Dashboard{}
+	dashboard.Name = template.Name
+	dashboard.ProjectId = projectId
+	dashboard.Widgets = []api.Widget{}
+	values := map[string]interface{}{"projectId": projectId}
+	for _, widgetTemplate := range template.Widgets {
+		if widgetTemplate.Assets == nil {
+			widget, err := renderWidget(widgetTemplate, values, nil)
+			if err != nil {
+				return api.Dashboard{}, true, err
+			}
+			dashboard.Widgets = append(dashboard.Widgets, widget)
+			continue
+		}
+		assets, err := queryAssets(*widgetTemplate.Assets, projectId, filter)
+		if err != nil {
+			return api.Dashboard{}, true, err
+		}
+		for _, asset := range assets {
+			var children []api.Asset
+			if widgetTemplate.Assets.Children {
+				children = asset.ChildrenInfo
+			}
+			widget, err := renderWidget(widgetTemplate, assetValues(values, "asset", asset), children)
+			if err != nil {
+				return api.Dashboard{}, true, err
+			}
+			dashboard.Widgets = append(dashboard.Widgets, widget)
+		}
+	}
+	return dashboard, true, nil
+}
+
+// queryAssets reads the assets of the project selected by the query and within the scope of the filter
+func queryAssets(query assetQuery, projectId string, filter *scopeFilter) ([]api.Asset, error) {
+	if query.Sort != "" && query.Sort != sortHealth {
+		return nil, fmt.Errorf("unknown sort order %s", query.Sort)
+	}
+	assetTypes := query.AssetTypes
+	kinds := query.Kinds
+	if query.Kind != "" {
+		kinds = append([]string{query.Kind}, kinds...)
+	}
+	for _, name := range kinds {
+		kind, ok := deviceKindByName(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown device kind %s", name)
+		}
+		assetTypes = append(assetTypes, kind.AssetTypes()...)
+	}
+	var assets []api.Asset
+	for _, assetType := range assetTypes {
+		request := client.NewClient().AssetsAPI.
+			GetAssets(client.AuthenticationContext()).
+			AssetTypeName(assetType).
+			ProjectId(projectId)
+		if query.Children || filter.needsChildren() {
+			request = request.Expansions([]string{"Asset.childrenInfo"})
+		}
+		found, _, err := request.Execute()
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, found...)
+	}
+	assets, err := filter.apply(assets)
+	if err != nil {
+		return nil, err
+	}
+	if query.Sort == sortHealth {
+		var assetIds []int32
+		for _, asset := range assets {
+			assetIds = append(assetIds, asset.GetId())
+		}
+		data, err := currentData(assetIds, assetTypes)
+		if err != nil {
+			return nil, err
+		}
+		sortByHealth(assets, data)
+	}
+	return assets, nil
+}
+
+// renderWidget replaces the placeholders in the widget template and adds the child data for each child asset
+func renderWidget(template widgetTemplate, values map[string]interface{}, children []api.Asset) (api.Widget, error) {
+	rendered := replacePlaceholders(template.Widget, values).(map[string]interface{})
+	data, _ := rendered["data"].([]interface{})
+	for _, child := range children {
+		childValues := assetValues(values, "child", child)
+		for _, childData := range template.ChildData {
+			data = append(data, replacePlaceholders(childData, childValues))
+		}
+	}
+	if data != nil {
+		rendered["data"] = data
+	}
+	var widget api.Widget
+	content, err := json.Marshal(rendered)
+	if err != nil {
+		return widget, err
+	}
+	err = json.Unmarshal(content, &widget)
+	return widget, err
+}
+
+// assetValues adds the values of the asset to the placeholder values using the given prefix
+func assetValues(values map[string]interface{}, prefix string, asset api.Asset) map[string]interface{} {
+	assetValues := make(map[string]interface{})
+	for key, value := range values {
+		assetValues[key] = value
+	}
+	assetValues[prefix+".id"] = asset.Id.Get()
+	assetValues[prefix+".name"] = asset.Name.Get()
+	assetValues[prefix+".description"] = asset.Description.Get()
+	assetValues[prefix+".gai"] = asset.GlobalAssetIdentifier
+	assetValues[prefix+".assetType"] = asset.AssetType
+	return assetValues
+}
+
+// replacePlaceholders replaces all placeholders in the template values. A string consisting only of a placeholder
+// is replaced by the value itself, so numbers stay numbers. Otherwise, the placeholder is replaced as text.
+func replacePlaceholders(template interface{}, values map[string]interface{}) interface{} {
+	switch t := template.(type) {
+	case map[string]interface{}:
+		replaced := make(map[string]interface{})
+		for key, value := range t {
+			replaced[key] = replacePlaceholders(value, values)
+		}
+		return replaced
+	case []interface{}:
+		var replaced []interface{}
+		for _, value := range t {
+			replaced = append(replaced, replacePlaceholders(value, values))
+		}
+		return replaced
+	case string:
+		if match := placeholderPattern.FindStringSubmatch(t); match != nil && match[0] == strings.TrimSpace(t) {
+			return placeholderValue(values, match[1])
+		}
+		return placeholderPattern.ReplaceAllStringFunc(t, func(placeholder string) string {
+			value := placeholderValue(values, placeholderPattern.FindStringSubmatch(placeholder)[1])
+			if value == nil {
+				return ""
+			}
+			return fmt.Sprint(value)
+		})
+	}
+	return template
+}
+
+// placeholderValue returns the value for the placeholder, pointers are dereferenced
+func placeholderValue(values map[string]interface{}, name string) interface{} {
+	switch value := values[name].(type) {
+	case *int32:
+		if value == nil {
+			return nil
+		}
+		return *value
+	case *string:
+		if value == nil {
+			return nil
+		}
+		return *value
+	default:
+		return value
+	}
+}
@@ -0,0 +1,72 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"encoding/json"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+)
+
+func TestDashboardTemplatesRegistered(t *testing.T) {
+	names, err := DashboardTemplateNames()
+	assert.Nil(t, err)
+	assert.Contains(t, names, "Hailo Smart Waste")
+	assert.Contains(t, names, "Hailo Fleet Health")
+
+	var metadata struct {
+		DashboardTemplateNames []string `json:"dashboardTemplateNames"`
+	}
+	content, err := os.ReadFile("../metadata.json")
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(content, &metadata))
+	assert.Equal(t, names, metadata.DashboardTemplateNames, "run go generate to register the templates in metadata.json")
+}
+
+func TestRenderWidget(t *testing.T) {
+	templates, err := readDashboardTemplates()
+	assert.Nil(t, err)
+	stationTemplate := templates["Hailo Smart Waste"].Widgets[1]
+
+	station := api.Asset{Id: *api.NewNullableInt32(common.Ptr[int32](4711))}
+	child := api.Asset{Id: *api.NewNullableInt32(common.Ptr[int32](4712)), Description: *api.NewNullableString(common.Ptr("Paper"))}
+	widget, err := renderWidget(stationTemplate, assetValues(map[string]interface{}{}, "asset", station), []api.Asset{child})
+	assert.Nil(t, err)
+	assert.Equal(t, "Hailo Station", widget.WidgetTypeName)
+	assert.Equal(t, int32(4711), *widget.AssetId.Get())
+	assert.Len(t, widget.Data, 3)
+	assert.Equal(t, int32(4712), *widget.Data[2].AssetId.Get())
+	assert.Equal(t, "Paper", widget.Data[2].Data["description"])
+
+	var sequences []int32
+	for _, data := range templates["Hailo Smart Waste"].Widgets[0].Widget["data"].([]interface{}) {
+		sequence := int32(data.(map[string]interface{})["elementSequence"].(float64))
+		assert.NotContains(t, sequences, sequence)
+		sequences = append(sequences, sequence)
+	}
+}
+
+func TestReplacePlaceholders(t *testing.T) {
+	values := map[string]interface{}{"asset.id": common.Ptr[int32](42), "asset.name": common.Ptr("Bin")}
+	assert.Equal(t, int32(42), replacePlaceholders("{{asset.id}}", values))
+	assert.Equal(t, "Bin 42", replacePlaceholders("{{ asset.name }} {{asset.id}}", values))
+	assert.Equal(t, "x", replacePlaceholders("{{unknown}}x", values))
+	assert.Nil(t, replacePlaceholders("{{unknown}}", values))
+}
@@ -0,0 +1,97 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"context"
+	"hailo/analytics"
+	"hailo/apiserver"
+	"hailo/conf"
+	"hailo/hailo"
+	"sync"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// usageCounter is the total openings counter of a device at the last contact
+type usageCounter struct {
+	lastContact   time.Time
+	totalOpenings int32
+}
+
+// recordedUsages holds the counter last stored in the usages for each device
+var recordedUsages sync.Map
+
+// recordUsage derives the openings since the last reading of the device from the total openings counter and adds
+// them to the hourly usages stored with the kind of the device (bin or station). The first reading and readings after
+// a reset of the counter only store the counter. The counter last stored is kept in memory, so the usages are only
+// read after a restart of the app.
+func recordUsage(config apiserver.Configuration, kind string, deviceId string, totalOpenings int, lastContact hailo.Time) {
+	if !lastContact.Valid {
+		return
+	}
+	key := registeredDeviceKey{common.Val(config.Id), deviceId}
+	value, found := recordedUsages.Load(key)
+	if found && !lastContact.Time.After(value.(usageCounter).lastContact) {
+		return
+	}
+	ctx := context.Background()
+	if !found {
+		last, err := conf.GetLastUsage(ctx, common.Val(config.Id), deviceId)
+		if err != nil {
+			log.Error("Hailo", "Could not read usage of device %s: %v", deviceId, err)
+			return
+		}
+		if last != nil {
+			value, found = usageCounter{lastContact: last.LastContact, totalOpenings: last.TotalOpenings}, true
+			recordedUsages.Store(key, value)
+			if !lastContact.Time.After(last.LastContact) {
+				return
+			}
+		}
+	}
+	usage := conf.Usage{
+		ConfigId:      common.Val(config.Id),
+		DeviceId:      deviceId,
+		Kind:          kind,
+		TotalOpenings: int32(totalOpenings),
+		LastContact:   lastContact.Time,
+	}
+	if device, ok := registeredDeviceOf(config, deviceId); ok {
+		usage.StationId = device.parentId
+	}
+	hours := map[time.Time]int32{lastContact.Time.Truncate(time.Hour): 0}
+	if found && usage.TotalOpenings >= value.(usageCounter).totalOpenings {
+		last := value.(usageCounter)
+		if spread := analytics.SpreadOpenings(last.lastContact, lastContact.Time, usage.TotalOpenings-last.totalOpenings); spread != nil {
+			for hour, openings := range spread {
+				hours[hour] = openings
+			}
+		}
+	}
+	for hour, openings := range hours {
+		usage.Hour = hour
+		usage.Openings = openings
+		err := conf.AddUsage(ctx, usage)
+		if err != nil {
+			log.Error("Hailo", "Could not store usage of device %s: %v", deviceId, err)
+			return
+		}
+	}
+	recordedUsages.Store(key, usageCounter{lastContact: lastContact.Time, totalOpenings: usage.TotalOpenings})
+}
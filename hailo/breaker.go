@@ -0,0 +1,124 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package hailo
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/volatiletech/null/v8"
+	"hailo/apiserver"
+)
+
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// ErrCircuitOpen is returned for requests to an FDS endpoint while the circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// circuitBreaker counts consecutive failed requests to an FDS endpoint. If the threshold is reached, the breaker
+// opens and rejects all requests until the probe interval is over. Then a single probe request is allowed. If the
+// probe succeeds the breaker closes, otherwise it opens again.
+type circuitBreaker struct {
+	mutex    sync.Mutex
+	state    string
+	failures int32
+	openedAt time.Time
+	probing  bool
+}
+
+// breakers holds a circuit breaker for each configuration
+var breakers sync.Map
+
+func getBreaker(config apiserver.Configuration) *circuitBreaker {
+	breaker, _ := breakers.LoadOrStore(null.Int64FromPtr(config.Id).Int64, &circuitBreaker{state: CircuitClosed})
+	return breaker.(*circuitBreaker)
+}
+
+// CircuitState returns the state of the circuit breaker for the configuration
+func CircuitState(config apiserver.Configuration) string {
+	breaker := getBreaker(config)
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	return breaker.state
+}
+
+// allowRequest checks if a request to the FDS endpoint of the configuration is allowed
+func allowRequest(config apiserver.Configuration) bool {
+	breaker := getBreaker(config)
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	switch breaker.state {
+	case CircuitOpen:
+		if time.Since(breaker.openedAt) < time.Duration(config.BreakerProbeSec)*time.Second {
+			return false
+		}
+		log.Info("Hailo", "Circuit breaker for config %d probes the FDS endpoint", null.Int64FromPtr(config.Id).Int64)
+		breaker.state = CircuitHalfOpen
+		breaker.probing = true
+		return true
+	case CircuitHalfOpen:
+		if breaker.probing {
+			return false
+		}
+		breaker.probing = true
+		return true
+	}
+	return true
+}
+
+// recordSuccess closes the circuit breaker after a successful request
+func recordSuccess(config apiserver.Configuration) {
+	breaker := getBreaker(config)
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	if breaker.state != CircuitClosed {
+		log.Info("Hailo", "Circuit breaker for config %d closed", null.Int64FromPtr(config.Id).Int64)
+	}
+	breaker.state = CircuitClosed
+	breaker.failures = 0
+	breaker.probing = false
+}
+
+// recordFailure counts a failed request and opens the circuit breaker if necessary
+func recordFailure(config apiserver.Configuration) {
+	breaker := getBreaker(config)
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	breaker.failures++
+	breaker.probing = false
+	if breaker.state == CircuitHalfOpen || breaker.failures >= config.BreakerThreshold {
+		if breaker.state != CircuitOpen {
+			log.Warn("Hailo", "Circuit breaker for config %d opened after %d failed requests", null.Int64FromPtr(config.Id).Int64, breaker.failures)
+		}
+		breaker.state = CircuitOpen
+		breaker.openedAt = time.Now()
+	}
+}
+
+// releaseProbe allows the next request to probe the FDS endpoint, if the probe request was not sent, e.g. because no
+// token could be created. The state of the circuit breaker is kept.
+func releaseProbe(config apiserver.Configuration) {
+	breaker := getBreaker(config)
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	breaker.probing = false
+}
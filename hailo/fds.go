@@ -18,7 +18,10 @@ package hailo
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"hailo/apiserver"
+	nethttp "net/http"
 	"strings"
 	"sync"
 	"time"
@@ -51,13 +54,17 @@ type Specs struct {
 }
 
 type Spec struct {
-	DeviceId string `json:"device_id"`
+	DeviceId string                 `json:"device_id"`
+	Raw      map[string]interface{} `json:"-"`
 	Generic  struct {
-		DeviceType       string `json:"device_type"`
-		RegistrationDate string `json:"registration_date"`
-		DeviceSerial     string `json:"device_serial"`
-		Manufacturer     string `json:"manufacturer"`
-		Model            string `json:"model"`
+		DeviceType       string    `json:"device_type"`
+		RegistrationDate Time      `json:"registration_date"`
+		DeviceSerial     string    `json:"device_serial"`
+		Manufacturer     string    `json:"manufacturer"`
+		Model            string    `json:"model"`
+		FirmwareVersion  string    `json:"firmware_version"`
+		HardwareVersion  string    `json:"hardware_version"`
+		Location         *Position `json:"location"`
 	} `json:"generic"`
 	DeviceTypeSpecific struct {
 		BinVolume           int    `json:"bin_volume"`
@@ -74,108 +81,240 @@ type Statuses struct {
 }
 
 type Status struct {
-	DeviceId string `json:"device_id"`
+	DeviceId string                 `json:"device_id"`
+	Raw      map[string]interface{} `json:"-"`
 	Generic  struct {
-		LastContact string `json:"last_contact"`
+		LastContact     Time      `json:"last_contact"`
+		SignalStrength  *float64  `json:"signal_strength"`
+		FirmwareVersion string    `json:"firmware_version"`
+		GpsPosition     *Position `json:"gps_position"`
 	} `json:"generic"`
 	DeviceTypeSpecific struct {
 		// Single Container
-		BatteryLevel   float32 `json:"battery_level"`
-		InputCount     int     `json:"inputs_count"`
-		LastEmptyCount int     `json:"last_empty_count"`
-		BinAlarm       bool    `json:"bin_alarm"`
+		BatteryLevel   float32  `json:"battery_level"`
+		InputCount     int      `json:"inputs_count"`
+		LastEmptyCount int      `json:"last_empty_count"`
+		BinAlarm       bool     `json:"bin_alarm"`
+		Temperature    *float64 `json:"temperature"`
+		FireAlarm      *bool    `json:"fire_alarm"`
+		TiltAlarm      *bool    `json:"tilt_alarm"`
+		LidState       string   `json:"lid_state"`
 		// Station
 		AverageBatteryLevel interface{} `json:"average_battery_level"`
 		AverageFillingLevel interface{} `json:"average_filling_level"`
 		TotalInputsCount    int         `json:"total_inputs_count"`
 		CompStatuses        []Status    `json:"component_statuses"`
 		// Both
-		FillingLevel []struct {
-			Level float32 `json:"level"`
-		} `json:"filling_level"`
+		FillingLevel []FillingLevel `json:"filling_level"`
 	} `json:"device_type_specific"`
 }
 
+// Position is a geographic position of a device. The coordinates are WGS 84 in decimal degrees.
+type Position struct {
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+	Address   string   `json:"address"`
+}
+
+// FillingLevel is the reading of one fill level sensor. Containers can be equipped with multiple sensors.
+type FillingLevel struct {
+	Level     float32 `json:"level"`
+	SensorId  string  `json:"sensor_id"`
+	Position  string  `json:"position"`
+	Timestamp Time    `json:"timestamp"`
+}
+
 type Diags struct {
 	Data []Diag `json:"data"`
 }
 
 type Diag struct {
-	DeviceId string `json:"device_id"`
+	DeviceId string                 `json:"device_id"`
+	Raw      map[string]interface{} `json:"-"`
 	Generic  struct {
-		LastService string `json:"last_service"`
+		LastService  Time     `json:"last_service"`
+		SensorErrors []string `json:"sensor_errors"`
 	} `json:"generic"`
 	DeviceTypeSpecific struct {
 		// Single Container
-		ExpectedNextService  string  `json:"expected_next_service"`
+		ExpectedNextService  Time    `json:"expected_next_service"`
 		ExpectedFillingLevel float32 `json:"expected_filling_level"`
 		// Station
-		StationExpectedNextService  string  `json:"station_expected_next_service"`
+		StationExpectedNextService  Time    `json:"station_expected_next_service"`
 		AverageExpectedFillingLevel float32 `json:"average_expected_filling_level"`
 	} `json:"device_type_specific"`
 	ComponentDiagnostics []Diag `json:"component_diagnostics"`
 }
 
-// tokens holds generated tokens for further use until they come invalid
+// ErrNoData is returned if the FDS endpoint returns no data for a requested device
+var ErrNoData = errors.New("no data returned")
+
+// tokens holds generated tokens for each configuration for further use until they come invalid
 var tokens sync.Map
 
-// GetSpecs reads the specification for all Hailo smart devices from eliona endpoint
-func GetSpecs(config apiserver.Configuration) (Specs, error) {
-	request, err := http.NewRequestWithBearer(
-		null.StringFromPtr(config.FdsServer).String+FdsSpecificationPath,
-		getToken(config),
-	)
-	if err != nil {
-		return Specs{}, err
-	}
+// tokenMutexes holds a mutex for each configuration, which prevents parallel authentications if multiple devices are
+// processed at the same time. Other configurations are not blocked by a slow authentication.
+var tokenMutexes sync.Map
 
-	specs, err := http.Read[Specs](request, time.Duration(config.RequestTimeout)*time.Second, true)
-	if err != nil {
-		return specs, err
-	}
+// authError is returned if no token could be created for a request to the FDS endpoint
+type authError struct {
+	err error
+}
+
+func (e *authError) Error() string {
+	return fmt.Sprintf("authentication: %v", e.err)
+}
+
+func (e *authError) Unwrap() error {
+	return e.err
+}
 
-	return specs, nil
+// GetSpecs reads the specification for all Hailo smart devices from eliona endpoint
+func GetSpecs(config apiserver.Configuration) (Specs, error) {
+	return read[Specs](config, FdsSpecificationPath)
 }
 
 // GetDiag reads the diagnostic data for the given device id
 func GetDiag(config apiserver.Configuration, deviceId string) (Diag, error) {
-	request, err := http.NewRequestWithBearer(
-
-		null.StringFromPtr(config.FdsServer).String+FdsDiagnosticsPath+FdsIdParam+deviceId,
-		getToken(config),
-	)
+	diagnostics, err := read[Diags](config, FdsDiagnosticsPath+FdsIdParam+deviceId)
 	if err != nil {
 		return Diag{}, err
 	}
-
-	diagnostics, err := http.Read[Diags](request, time.Duration(config.RequestTimeout)*time.Second, true)
-	if err != nil {
-		return Diag{}, err
+	if len(diagnostics.Data) == 0 {
+		return Diag{}, fmt.Errorf("diagnostics for device %s: %w", deviceId, ErrNoData)
 	}
 	return diagnostics.Data[0], nil
 }
 
 // GetStatus reads the status data for the given device id
 func GetStatus(config apiserver.Configuration, deviceId string) (Status, error) {
-	request, err := http.NewRequestWithBearer(
-		null.StringFromPtr(config.FdsServer).String+FdsStatusPath+FdsIdParam+deviceId,
-		getToken(config),
-	)
+	statuses, err := read[Statuses](config, FdsStatusPath+FdsIdParam+deviceId)
 	if err != nil {
 		return Status{}, err
 	}
+	if len(statuses.Data) == 0 {
+		return Status{}, fmt.Errorf("status for device %s: %w", deviceId, ErrNoData)
+	}
+	return statuses.Data[0], nil
+}
 
-	statuses, err := http.Read[Statuses](request, time.Duration(config.RequestTimeout)*time.Second, true)
+// read requests the given path from the FDS endpoint and decodes the response
+func read[T any](config apiserver.Configuration, path string) (T, error) {
+	var value T
+	url := null.StringFromPtr(config.FdsServer).String + path
+	payload, err := doWithBreaker(config, url, time.Duration(config.RequestTimeout)*time.Second, func() (*nethttp.Request, error) {
+		token, err := getToken(config)
+		if err != nil {
+			return nil, &authError{err: err}
+		}
+		return http.NewRequestWithBearer(url, token)
+	})
+	if requestErr, ok := err.(*requestError); ok && requestErr.statusCode == nethttp.StatusUnauthorized {
+		// Forget the token, so that a new token is created for the next request
+		tokens.Delete(null.Int64FromPtr(config.Id).Int64)
+	}
 	if err != nil {
-		return Status{}, err
+		return value, err
+	}
+	err = json.Unmarshal(payload, &value)
+	if err != nil {
+		return value, fmt.Errorf("unmarshaling: %v", err)
 	}
+	return value, nil
+}
 
-	return statuses.Data[0], nil
+// UnmarshalJSON decodes the specification and keeps the raw document for mapping fields to attributes
+func (spec *Spec) UnmarshalJSON(data []byte) error {
+	type plain Spec
+	return unmarshalWithRaw(data, (*plain)(spec), &spec.Raw)
+}
+
+// UnmarshalJSON decodes the status and keeps the raw document for mapping fields to attributes
+func (status *Status) UnmarshalJSON(data []byte) error {
+	type plain Status
+	return unmarshalWithRaw(data, (*plain)(status), &status.Raw)
 }
 
-// IsStation returns true, if the status is from a station. A station contains multiple component statuses.
-func (status Status) IsStation() bool {
-	return len(status.DeviceTypeSpecific.CompStatuses) > 0
+// UnmarshalJSON decodes the diagnostic and keeps the raw document for mapping fields to attributes
+func (diag *Diag) UnmarshalJSON(data []byte) error {
+	type plain Diag
+	return unmarshalWithRaw(data, (*plain)(diag), &diag.Raw)
+}
+
+func unmarshalWithRaw(data []byte, value any, raw *map[string]interface{}) error {
+	err := json.Unmarshal(data, value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, raw)
+}
+
+// UnknownFields returns all fields of the raw specification which are not decoded, e.g. to find new FDS fields
+// which can be mapped to attributes. The fields are returned by their dot separated path.
+func (spec Spec) UnknownFields() map[string]interface{} {
+	return unknownFields(spec.Raw, spec)
+}
+
+// UnknownFields returns all fields of the raw status which are not decoded by their dot separated path
+func (status Status) UnknownFields() map[string]interface{} {
+	return unknownFields(status.Raw, status)
+}
+
+// UnknownFields returns all fields of the raw diagnostic which are not decoded by their dot separated path
+func (diag Diag) UnknownFields() map[string]interface{} {
+	return unknownFields(diag.Raw, diag)
+}
+
+func unknownFields(raw map[string]interface{}, value any) map[string]interface{} {
+	encoded, _ := json.Marshal(value)
+	var known map[string]interface{}
+	_ = json.Unmarshal(encoded, &known)
+	unknown := make(map[string]interface{})
+	diffFields("", raw, known, unknown)
+	return unknown
+}
+
+func diffFields(prefix string, raw interface{}, known interface{}, unknown map[string]interface{}) {
+	switch rawValue := raw.(type) {
+	case map[string]interface{}:
+		knownMap, _ := known.(map[string]interface{})
+		for key, value := range rawValue {
+			if knownValue, ok := knownMap[key]; ok {
+				diffFields(prefix+key+".", value, knownValue, unknown)
+			} else {
+				unknown[prefix+key] = value
+			}
+		}
+	case []interface{}:
+		knownArray, _ := known.([]interface{})
+		for i, value := range rawValue {
+			if i < len(knownArray) {
+				diffFields(fmt.Sprintf("%s%d.", prefix, i), value, knownArray[i], unknown)
+			}
+		}
+	}
+}
+
+// ComponentDiag returns the diagnostic of the component with the given device id embedded in the diagnostic of a
+// station. If the component is not embedded, false is returned.
+func (diag Diag) ComponentDiag(deviceId string) (Diag, bool) {
+	for _, componentDiag := range diag.ComponentDiagnostics {
+		if componentDiag.DeviceId == deviceId {
+			return componentDiag, true
+		}
+	}
+	return Diag{}, false
+}
+
+// ComponentSpec returns the specification of the component with the given device id listed in the specification of a
+// station. If the component is not listed, a specification with only the device id is returned.
+func (spec Spec) ComponentSpec(deviceId string) Spec {
+	for _, componentSpec := range spec.DeviceTypeSpecific.ComponentIdList {
+		if componentSpec.DeviceId == deviceId {
+			return componentSpec
+		}
+	}
+	return Spec{DeviceId: deviceId}
 }
 
 // isTokenValid checks if the given token is valid
@@ -184,6 +323,9 @@ func isTokenValid(token string) bool {
 
 	start := strings.Index(token, ".")
 	end := strings.LastIndex(token, ".")
+	if start < 0 || end <= start {
+		return false
+	}
 
 	jwtBody := token[start+1 : end]
 	jwtBody = decodeBase64(jwtBody)
@@ -207,34 +349,37 @@ func isTokenValid(token string) bool {
 }
 
 // getToken creates a new token or delivers a previous token until this token is valid
-func getToken(config apiserver.Configuration) string {
-	token, found := tokens.Load(config.Id)
+func getToken(config apiserver.Configuration) (string, error) {
+	configId := null.Int64FromPtr(config.Id).Int64
+	mutex, _ := tokenMutexes.LoadOrStore(configId, &sync.Mutex{})
+	mutex.(*sync.Mutex).Lock()
+	defer mutex.(*sync.Mutex).Unlock()
+	token, found := tokens.Load(configId)
 	if found {
 		if isTokenValid(token.(string)) {
-			return token.(string)
+			return token.(string), nil
 		}
 	}
-	token, _ = authenticate(config)
-	tokens.Store(config.Id, token)
-	return token.(string)
+	newToken, err := authenticate(config)
+	if err != nil {
+		log.Error("Hailo", "Could not authenticate for config %d: %v", configId, err)
+		return "", err
+	}
+	tokens.Store(configId, newToken)
+	return newToken, nil
 }
 
 // authenticate creates a new token
 func authenticate(config apiserver.Configuration) (string, error) {
 
 	log.Info("Hailo", "Create new Authentication token")
-	request, err := http.NewPostRequest(
-		null.StringFromPtr(config.AuthServer).String+AuthApiPath,
-		auth{
+	url := null.StringFromPtr(config.AuthServer).String + AuthApiPath
+	token, err := doWithRetry(config, url, time.Duration(config.AuthTimeout)*time.Second, func() (*nethttp.Request, error) {
+		return http.NewPostRequest(url, auth{
 			UserName: null.StringFromPtr(config.Username).String,
 			Password: null.StringFromPtr(config.Password).String,
-		},
-	)
-	if err != nil {
-		return "", err
-	}
-
-	token, err := http.Do(request, time.Duration(config.AuthTimeout)*time.Second, true)
+		})
+	})
 	if err != nil {
 		return "", err
 	}
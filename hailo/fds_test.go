@@ -1,6 +1,7 @@
 package hailo
 
 import (
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -11,3 +12,28 @@ func TestCodingBase64(t *testing.T) {
 	dec := decodeBase64(enc)
 	assert.Equal(t, dec, s)
 }
+
+func TestComponentDiag(t *testing.T) {
+	var stationDiag Diag
+	err := json.Unmarshal([]byte(`{"device_id":"station","component_diagnostics":[{"device_id":"bin-1","device_type_specific":{"expected_filling_level":0.5}}]}`), &stationDiag)
+	assert.Nil(t, err)
+
+	diag, ok := stationDiag.ComponentDiag("bin-1")
+	assert.True(t, ok)
+	assert.Equal(t, float32(0.5), diag.DeviceTypeSpecific.ExpectedFillingLevel)
+
+	_, ok = stationDiag.ComponentDiag("bin-2")
+	assert.False(t, ok)
+}
+
+func TestUnknownFields(t *testing.T) {
+	var status Status
+	err := json.Unmarshal([]byte(`{"device_id":"bin-1","generic":{"last_contact":"2021-01-26T09:16:16.000Z","signal_strength":-71,"uptime":42},"device_type_specific":{"battery_level":0.5,"filling_level":[{"level":0.5,"quality":"good"}]},"vendor":{"x":1}}`), &status)
+	assert.Nil(t, err)
+	assert.Equal(t, -71.0, *status.Generic.SignalStrength)
+	assert.Equal(t, map[string]interface{}{
+		"generic.uptime": 42.0,
+		"device_type_specific.filling_level.0.quality": "good",
+		"vendor": map[string]interface{}{"x": 1.0},
+	}, status.UnknownFields())
+}
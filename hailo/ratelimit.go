@@ -0,0 +1,65 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package hailo
+
+import (
+	"math"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket which allows a defined number of requests per second
+type rateLimiter struct {
+	mutex    sync.Mutex
+	tokens   float64
+	lastTime time.Time
+}
+
+// limiters holds a rate limiter for each FDS host
+var limiters sync.Map
+
+// waitForHost blocks until a further request to the host of the given url is allowed. The requests per second are
+// shared between all configurations using the same host.
+func waitForHost(rawUrl string, requestsPerSec int32) {
+	host := rawUrl
+	if parsed, err := url.Parse(rawUrl); err == nil {
+		host = parsed.Host
+	}
+	limiter, _ := limiters.LoadOrStore(host, &rateLimiter{tokens: 1, lastTime: time.Now()})
+	limiter.(*rateLimiter).wait(float64(requestsPerSec))
+}
+
+// wait takes a token from the bucket. If no token is available, wait blocks until the bucket is refilled.
+func (limiter *rateLimiter) wait(rate float64) {
+	if rate <= 0 {
+		return
+	}
+	for {
+		limiter.mutex.Lock()
+		now := time.Now()
+		limiter.tokens = math.Min(math.Max(rate, 1), limiter.tokens+now.Sub(limiter.lastTime).Seconds()*rate)
+		limiter.lastTime = now
+		if limiter.tokens >= 1 {
+			limiter.tokens--
+			limiter.mutex.Unlock()
+			return
+		}
+		delay := time.Duration((1 - limiter.tokens) / rate * float64(time.Second))
+		limiter.mutex.Unlock()
+		time.Sleep(delay)
+	}
+}
@@ -0,0 +1,16 @@
+package hailo
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := &rateLimiter{tokens: 1, lastTime: time.Now()}
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		limiter.wait(20)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
@@ -0,0 +1,148 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package hailo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"hailo/apiserver"
+)
+
+// maxRetryDelay limits the delay between two retries
+const maxRetryDelay = 2 * time.Minute
+
+// requestError is returned if a request was answered with an unsuccessful status code
+type requestError struct {
+	statusCode int
+	url        string
+}
+
+func (e *requestError) Error() string {
+	return fmt.Sprintf("error request code %d for request to %s", e.statusCode, e.url)
+}
+
+// doWithBreaker sends the request created by newRequest with retries. Network errors, server errors and too many
+// requests are counted as failures by the circuit breaker of the configuration. Failed authentications are not
+// counted, because the request was not sent. While the circuit breaker is open, no request is sent.
+func doWithBreaker(config apiserver.Configuration, rawUrl string, timeout time.Duration, newRequest func() (*http.Request, error)) ([]byte, error) {
+	if !allowRequest(config) {
+		return nil, ErrCircuitOpen
+	}
+	payload, err := doWithRetry(config, rawUrl, timeout, newRequest)
+	var authErr *authError
+	if errors.As(err, &authErr) {
+		// The FDS endpoint was not requested, so the failed authentication is not counted
+		releaseProbe(config)
+		return nil, err
+	}
+	if err != nil && isRetryable(err) {
+		recordFailure(config)
+		return nil, err
+	}
+	// Client errors like an unknown device id show that the FDS endpoint is reachable
+	recordSuccess(config)
+	return payload, err
+}
+
+// doWithRetry sends the request created by newRequest. If the request fails because of a network error, a server
+// error or too many requests, the request is retried with exponential backoff until the maximum number of retries
+// of the configuration is reached.
+func doWithRetry(config apiserver.Configuration, rawUrl string, timeout time.Duration, newRequest func() (*http.Request, error)) ([]byte, error) {
+	for attempt := int32(0); ; attempt++ {
+		waitForHost(rawUrl, config.RequestsPerSec)
+		request, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		payload, retryAfter, err := do(request, timeout)
+		if err == nil {
+			return payload, nil
+		}
+		if !isRetryable(err) || attempt >= common.Val(config.MaxRetries) {
+			return nil, err
+		}
+		delay := retryDelay(config, attempt, retryAfter)
+		log.Warn("Hailo", "Retry request to %s in %v (attempt %d of %d): %v", request.URL, delay, attempt+1, common.Val(config.MaxRetries), err)
+		time.Sleep(delay)
+	}
+}
+
+// do sends the request and returns the payload and the delay requested by a Retry-After header
+func do(request *http.Request, timeout time.Duration) ([]byte, time.Duration, error) {
+	client := http.Client{Timeout: timeout}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer response.Body.Close()
+	payload, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	if response.StatusCode >= 300 {
+		return nil, parseRetryAfter(response.Header.Get("Retry-After")), &requestError{statusCode: response.StatusCode, url: request.URL.String()}
+	}
+	return payload, 0, nil
+}
+
+// isRetryable returns true for network errors and status codes indicating a temporary problem
+func isRetryable(err error) bool {
+	if requestErr, ok := err.(*requestError); ok {
+		return requestErr.statusCode == http.StatusTooManyRequests ||
+			requestErr.statusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// retryDelay calculates the exponential backoff with jitter for the given attempt. If the server requested a longer
+// delay with a Retry-After header, this delay is used.
+func retryDelay(config apiserver.Configuration, attempt int32, retryAfter time.Duration) time.Duration {
+	delay := time.Duration(config.RetryDelaySec) * time.Second << attempt
+	if delay < 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	delay += time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	if retryAfter > delay {
+		return retryAfter
+	}
+	return delay
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number of seconds or a http date. The
+// delay is limited to the maximum retry delay.
+func parseRetryAfter(value string) time.Duration {
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = time.Until(date)
+	}
+	if delay < 0 {
+		return 0
+	}
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
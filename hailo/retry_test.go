@@ -0,0 +1,84 @@
+package hailo
+
+import (
+	"errors"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/stretchr/testify/assert"
+	"hailo/apiserver"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	config := apiserver.Configuration{Id: common.Ptr[int64](1), MaxRetries: common.Ptr[int32](3), BreakerThreshold: 1, BreakerProbeSec: 60}
+	payload, err := doWithBreaker(config, server.URL, time.Second, func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, server.URL, nil)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", string(payload))
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, CircuitClosed, CircuitState(config))
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	requests := 0
+	status := http.StatusNotFound
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	config := apiserver.Configuration{Id: common.Ptr[int64](2), MaxRetries: common.Ptr[int32](0), BreakerThreshold: 2, BreakerProbeSec: 60}
+	newRequest := func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, server.URL, nil)
+	}
+
+	// client errors like unknown devices don't open the circuit breaker
+	for i := 0; i < 3; i++ {
+		_, err := doWithBreaker(config, server.URL, time.Second, newRequest)
+		assert.NotNil(t, err)
+	}
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, CircuitClosed, CircuitState(config))
+
+	// server errors do
+	status = http.StatusServiceUnavailable
+	for i := 0; i < 3; i++ {
+		_, err := doWithBreaker(config, server.URL, time.Second, newRequest)
+		assert.NotNil(t, err)
+	}
+	assert.Equal(t, 5, requests)
+	assert.Equal(t, CircuitOpen, CircuitState(config))
+}
+
+func TestAuthFailure(t *testing.T) {
+	config := apiserver.Configuration{Id: common.Ptr[int64](3), MaxRetries: common.Ptr[int32](0), BreakerThreshold: 1, BreakerProbeSec: 60}
+	for i := 0; i < 3; i++ {
+		_, err := doWithBreaker(config, "http://localhost", time.Second, func() (*http.Request, error) {
+			return nil, &authError{err: errors.New("auth server not reachable")}
+		})
+		assert.NotNil(t, err)
+	}
+	assert.Equal(t, CircuitClosed, CircuitState(config))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, 5*time.Second, parseRetryAfter("5"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, maxRetryDelay, parseRetryAfter("86400"))
+	assert.InDelta(t, float64(time.Minute), float64(parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))), float64(2*time.Second))
+}
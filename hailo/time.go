@@ -0,0 +1,93 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package hailo
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// timeLayouts are the formats FDS uses for timestamps, e.g. 2021-01-26T09:16:16.000Z, 2021-01-26T09:16:16+01:00 or
+// 2021-01-26T09:16:16 (UTC)
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// Time is a timestamp read from FDS. If the timestamp is missing, null or cannot be parsed, the time is not valid.
+type Time struct {
+	time.Time
+	Valid bool
+}
+
+// ParseTime parses a timestamp in one of the formats used by FDS. Numbers are handled as unix time in milliseconds.
+// An empty string results in an invalid time without error.
+func ParseTime(value string) (Time, error) {
+	if value == "" {
+		return Time{}, nil
+	}
+	var err error
+	for _, layout := range timeLayouts {
+		var t time.Time
+		t, err = time.Parse(layout, value)
+		if err == nil {
+			return Time{Time: t, Valid: true}, nil
+		}
+	}
+	if millis, parseErr := strconv.ParseInt(value, 10, 64); parseErr == nil {
+		return Time{Time: time.UnixMilli(millis).UTC(), Valid: true}, nil
+	}
+	return Time{}, err
+}
+
+// UnmarshalJSON decodes a timestamp. Timestamps which cannot be parsed are logged and handled as missing, so that
+// one wrong timestamp does not prevent reading the whole document.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	*t = Time{}
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var value string
+	if len(data) > 0 && data[0] == '"' {
+		err := json.Unmarshal(data, &value)
+		if err != nil {
+			return err
+		}
+	} else {
+		value = string(data)
+	}
+	parsed, err := ParseTime(value)
+	if err != nil {
+		log.Warn("Hailo", "Ignore invalid timestamp %s: %v", data, err)
+		return nil
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON encodes the timestamp in ISO 8601 or null, if the time is not valid
+func (t Time) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Time.Format(time.RFC3339Nano))
+}
@@ -0,0 +1,61 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package hailo
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTimeUnmarshal(t *testing.T) {
+	var generic struct {
+		WithMillis    Time `json:"with_millis"`
+		WithoutMillis Time `json:"without_millis"`
+		WithOffset    Time `json:"with_offset"`
+		WithoutZone   Time `json:"without_zone"`
+		UnixMillis    Time `json:"unix_millis"`
+		Null          Time `json:"null"`
+		Empty         Time `json:"empty"`
+		Invalid       Time `json:"invalid"`
+		Missing       Time `json:"missing"`
+	}
+	err := json.Unmarshal([]byte(`{
+		"with_millis": "2021-01-26T09:16:16.000Z",
+		"without_millis": "2021-01-26T09:16:16Z",
+		"with_offset": "2021-01-26T10:16:16+01:00",
+		"without_zone": "2021-01-26T09:16:16",
+		"unix_millis": 1611652576000,
+		"null": null,
+		"empty": "",
+		"invalid": "yesterday"
+	}`), &generic)
+	assert.Nil(t, err)
+
+	expected := time.Date(2021, 1, 26, 9, 16, 16, 0, time.UTC)
+	for _, value := range []Time{generic.WithMillis, generic.WithoutMillis, generic.WithOffset, generic.WithoutZone, generic.UnixMillis} {
+		assert.True(t, value.Valid)
+		assert.True(t, expected.Equal(value.Time), value.Time.String())
+	}
+	for _, value := range []Time{generic.Null, generic.Empty, generic.Invalid, generic.Missing} {
+		assert.False(t, value.Valid)
+	}
+
+	encoded, err := json.Marshal(generic.Null)
+	assert.Nil(t, err)
+	assert.Equal(t, "null", string(encoded))
+}
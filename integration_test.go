@@ -44,10 +44,11 @@ func widgetTypes(t *testing.T) {
 
 	assert.WidgetTypeExists(t, "Hailo")
 	assert.WidgetTypeExists(t, "Hailo Station")
+	assert.WidgetTypeExists(t, "Hailo Health")
 }
 
 func schema(t *testing.T) {
 	t.Parallel()
 
-	assert.SchemaExists(t, "hailo", []string{"config", "asset"})
+	assert.SchemaExists(t, "hailo", []string{"config", "asset", "outbox", "reading", "battery_reading", "usage"})
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/eliona-smart-building-assistant/go-utils/common"
 	"github.com/eliona-smart-building-assistant/go-utils/log"
 	"hailo/conf"
+	"hailo/eliona"
 	"os"
 	"time"
 )
@@ -40,9 +41,12 @@ func main() {
 	// Initialize the app
 	initialization()
 
-	// Starting the service to collect the data for each configured Hailo Smart Hub.
+	// Starting the service to collect the data for each configured Hailo Smart Hub, the service to write
+	// data queued during outages of Eliona and the service to clean up the history of readings.
 	common.WaitForWithOs(
 		common.Loop(collectData, time.Second*60),
+		common.Loop(eliona.ReplayQueuedData, time.Second*30),
+		common.Loop(eliona.DeleteExpiredReadings, time.Hour),
 		listenApiRequests,
 	)
 
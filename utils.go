@@ -65,14 +65,27 @@ func printSpec(config apiserver.Configuration, spec hailo.Spec) {
 	fmt.Printf(" ---- Device %s ----\n", spec.DeviceId)
 	pretty, _ := json.MarshalIndent(spec, "", "\t")
 	fmt.Println(string(pretty))
+	printUnknownFields(spec.UnknownFields())
 
 	status, _ := hailo.GetStatus(config, spec.DeviceId)
 	fmt.Printf(" ---- Status %s ----\n", spec.DeviceId)
 	pretty, _ = json.MarshalIndent(status, "", "\t")
 	fmt.Println(string(pretty))
+	printUnknownFields(status.UnknownFields())
 
 	diag, _ := hailo.GetDiag(config, spec.DeviceId)
 	fmt.Printf(" ---- Diagnostic %s ----\n", spec.DeviceId)
 	pretty, _ = json.MarshalIndent(diag, "", "\t")
 	fmt.Println(string(pretty))
+	printUnknownFields(diag.UnknownFields())
+}
+
+// printUnknownFields prints out fields delivered by FDS, which are not decoded by the app
+func printUnknownFields(unknownFields map[string]interface{}) {
+	if len(unknownFields) == 0 {
+		return
+	}
+	fmt.Println(" ---- Unknown fields ----")
+	pretty, _ := json.MarshalIndent(unknownFields, "", "\t")
+	fmt.Println(string(pretty))
 }